@@ -0,0 +1,90 @@
+// Command godb1-gen introspects a live database's information_schema
+// and emits a typed Row/Patch struct plus Insert/UpdateByID/Find
+// wrapper functions per table, as a thin layer over gahooa/godb1's
+// Field/Where*/InsertRowReturning/UpdateRow/RowList primitives.
+//
+// Regenerating from the same schema and config always reproduces the
+// same output, so it is safe to run again every time the schema
+// changes. All of that lives in the importable gahooa/godb1/gen
+// package; this file is a template, not a ready-to-run binary -- it has
+// no database/sql driver registered, so copy this directory into your
+// own module, blank-import the driver -driver/-dsn need (e.g.
+// `_ "github.com/lib/pq"`), and build that copy.
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"gahooa/godb1"
+	"gahooa/godb1/gen"
+)
+
+func main() {
+	configPath := flag.String("config", "godb1-gen.json", "path to the codegen config file")
+	driver := flag.String("driver", "postgres", "database/sql driver name blank-imported by your copy of this file")
+	dsn := flag.String("dsn", "", "data source name passed to sql.Open")
+	dialectName := flag.String("dialect", "postgres", "SQL dialect: postgres, cockroachdb, mysql or sqlite")
+	flag.Parse()
+
+	if *dsn == "" {
+		fmt.Fprintln(os.Stderr, "godb1-gen: -dsn is required")
+		os.Exit(2)
+	}
+
+	cfg, err := gen.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("godb1-gen: %v", err)
+	}
+
+	d, err := dialectByName(*dialectName)
+	if err != nil {
+		log.Fatalf("godb1-gen: %v", err)
+	}
+
+	db, err := sql.Open(*driver, *dsn)
+	if err != nil {
+		log.Fatalf("godb1-gen: open %s: %v", *driver, err)
+	}
+	defer db.Close()
+
+	tables, err := gen.Introspect(db, d)
+	if err != nil {
+		log.Fatalf("godb1-gen: %v", err)
+	}
+
+	if err := os.MkdirAll(cfg.OutputDir, 0o755); err != nil {
+		log.Fatalf("godb1-gen: %v", err)
+	}
+
+	written := 0
+	for _, table := range tables {
+		if !cfg.Allowed(table.Name) {
+			continue
+		}
+		if err := gen.GenerateTable(cfg, table); err != nil {
+			log.Fatalf("godb1-gen: table %q: %v", table.Name, err)
+		}
+		written++
+	}
+
+	fmt.Printf("godb1-gen: wrote %d table(s) to %s\n", written, cfg.OutputDir)
+}
+
+func dialectByName(name string) (godb1.Dialect, error) {
+	switch name {
+	case "postgres":
+		return godb1.PostgresDialect, nil
+	case "cockroachdb":
+		return godb1.CockroachDialect, nil
+	case "mysql":
+		return godb1.MySQLDialect, nil
+	case "sqlite":
+		return godb1.SQLiteDialect, nil
+	default:
+		return godb1.Dialect{}, fmt.Errorf("unknown dialect %q", name)
+	}
+}
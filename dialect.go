@@ -0,0 +1,99 @@
+package godb1
+
+import "fmt"
+
+// A Dialect knows how to quote identifiers and render positional
+// placeholders for a particular database driver. Everything in this
+// package that needs to emit raw SQL syntax (identifier quoting,
+// placeholder style, upsert syntax, ...) should go through a Dialect
+// instead of hard-coding backticks or "?".
+type Dialect struct {
+	Name string
+
+	// QuoteIdent quotes a single identifier (table or column name).
+	QuoteIdent func(name string) string
+
+	// Placeholder returns the driver's positional placeholder for the
+	// n'th bound argument (1-indexed).
+	Placeholder func(n int) string
+
+	// MaxParams bounds how many positional args a single statement may
+	// bind, so insert_many can chunk large batches to stay under the
+	// driver's limit (Postgres hard-caps at 65535; MySQL has no fixed
+	// count but max_allowed_packet makes very wide statements unsafe,
+	// so the same conservative cap is used).
+	MaxParams int
+
+	// SupportsReturning reports whether INSERT ... RETURNING is valid
+	// syntax. MySQL has no RETURNING; callers fall back to
+	// sql.Result.LastInsertId.
+	SupportsReturning bool
+}
+
+// MySQLDialect quotes identifiers with backticks and uses "?" for every
+// positional placeholder.
+var MySQLDialect = Dialect{
+	Name: "mysql",
+	QuoteIdent: func(name string) string {
+		return "`" + name + "`"
+	},
+	Placeholder: func(n int) string {
+		return "?"
+	},
+	MaxParams:         65535,
+	SupportsReturning: false,
+}
+
+// PostgresDialect quotes identifiers with double quotes and uses
+// numbered "$1".."$N" placeholders.
+var PostgresDialect = Dialect{
+	Name: "postgres",
+	QuoteIdent: func(name string) string {
+		return `"` + name + `"`
+	},
+	Placeholder: func(n int) string {
+		return fmt.Sprintf("$%d", n)
+	},
+	MaxParams:         65535,
+	SupportsReturning: true,
+}
+
+// CockroachDialect is wire- and SQL-compatible with Postgres for
+// everything this package emits.
+var CockroachDialect = Dialect{
+	Name:              "cockroachdb",
+	QuoteIdent:        PostgresDialect.QuoteIdent,
+	Placeholder:       PostgresDialect.Placeholder,
+	MaxParams:         65535,
+	SupportsReturning: true,
+}
+
+// SQLiteDialect quotes identifiers with double quotes (ANSI-style, like
+// Postgres) but uses MySQL-style positional "?" placeholders.
+var SQLiteDialect = Dialect{
+	Name: "sqlite",
+	QuoteIdent: func(name string) string {
+		return `"` + name + `"`
+	},
+	Placeholder: func(n int) string {
+		return "?"
+	},
+	MaxParams:         65535,
+	SupportsReturning: true,
+}
+
+// defaultDialect is used by every builder in this package unless
+// overridden with SetDialect.
+var defaultDialect = MySQLDialect
+
+// SetDialect changes the dialect used by execute/value/row/row_list/
+// insert/update/delete for the remainder of the process.
+func SetDialect(d Dialect) {
+	defaultDialect = d
+}
+
+// kept for backwards compatibility with the original prototype; now a
+// thin wrapper around the configured dialect.
+func quote_ident(name string) string {
+	return defaultDialect.QuoteIdent(name)
+}
@@ -0,0 +1,177 @@
+// Command basic exercises every query-building helper in gahooa/godb1.
+// With no database configured (the default), Execute/Insert/Update/
+// Delete print the resolved SQL and bound args instead of running
+// anything, so this can be run with nothing more than:
+//
+//	go run ./examples/basic
+//
+// Setting BASIC_EXAMPLE_DSN additionally runs runWithConnection against
+// a real database, the same way cmd/godb1-gen works: this file has no
+// database/sql driver registered, so copy it into your own module,
+// blank-import the driver your DSN needs (e.g. `_ "github.com/lib/pq"`),
+// and build that copy.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	"gahooa/godb1"
+)
+
+func main() {
+	// Running a complex update with automation for {fields=values} and
+	// {where}, as well as $-params.
+	godb1.Execute(`
+        UPDATE
+            pod
+        SET
+            {field=value}
+        WHERE true
+            AND pod_cat_id = $pod_cat_id
+            AND pod_id = (select max(pod_id) from pod_network WHERE category = $category)
+            AND NOT deleted
+            AND {where}
+        `,
+		godb1.Field("active", true),
+		godb1.Field("name", "New Name"),
+		godb1.FieldSQL("age", 30, "$age + 1"),
+		godb1.Bind("pod_cat_id", 12345),
+		godb1.Bind("category", "test"),
+		godb1.WhereNotNull("error_message"),
+	)
+
+	// automatic insert construction
+	godb1.Insert("user",
+		godb1.Field("name", "Jason"),
+		godb1.Field("age", 30),
+	)
+
+	// example where we actually just pass actual sql to the update
+	godb1.Update("user",
+		godb1.Field("name", "New Name"),
+		godb1.FieldSQL("age", nil, "age + 1"),
+		godb1.WhereEq("id", 12345),
+		godb1.WhereEq("active", true),
+	)
+
+	// delete a record
+	godb1.Delete("user", godb1.WhereEq("id", 12345))
+
+	// PATCH semantics: only "name" was actually sent by the client, so
+	// "age" is left untouched and "bio" is explicitly nulled out.
+	godb1.Update("user",
+		godb1.FieldIfSet("name", "New Name", true),
+		godb1.FieldIfSet("age", 99, false),
+		godb1.FieldNull("bio"),
+		godb1.WhereEq("id", 12345),
+	)
+
+	// building Field() params straight from a struct, the way generated
+	// Insert wrappers do
+	type newUser struct {
+		Name string
+		Age  int
+	}
+	fields, err := godb1.FieldsFromStruct(newUser{Name: "Eve", Age: 24})
+	if err != nil {
+		fmt.Println("godb1: FieldsFromStruct error:", err)
+	} else {
+		godb1.Insert("user", fields...)
+	}
+
+	// batch insert, one round trip for all three rows
+	godb1.InsertMany("user", []map[string]interface{}{
+		{"name": "Alice", "age": 31},
+		{"name": "Bob", "age": 28},
+		{"name": "Carol", "age": 40},
+	})
+
+	// insert, or update in place if (name) already exists
+	godb1.Upsert("user", []string{"name"},
+		godb1.Field("name", "Alice"),
+		godb1.Field("age", 32),
+	)
+
+	// the extended where_* family: IN, BETWEEN, LIKE, raw SQL, and
+	// AND/OR grouping
+	godb1.Execute(`UPDATE user SET {field=value} WHERE {where}`,
+		godb1.Field("active", false),
+		godb1.WhereAny(
+			godb1.WhereAll(
+				godb1.WhereIn("status", []string{"active", "trial"}),
+				godb1.WhereBetween("age", 18, 65),
+			),
+			godb1.WhereLike("name", "A%"),
+			godb1.WhereRaw("lower(email) = ?", "admin@example.com"),
+		),
+		godb1.WhereNotIn("id", []int{4, 5, 6}),
+	)
+
+	// Using a real connection: transactions, typed scans and streaming rows.
+	runWithConnection()
+}
+
+// connectionOrNil opens BASIC_EXAMPLE_DSN if set, so this example stays
+// runnable with no database (every helper above just prints) while still
+// letting a real run exercise transactions and scanning. As noted on the
+// package doc comment, that requires building a copy of this file with
+// a "postgres" driver blank-imported -- this file itself registers none.
+func connectionOrNil() *sql.DB {
+	dsn := os.Getenv("BASIC_EXAMPLE_DSN")
+	if dsn == "" {
+		return nil
+	}
+	conn, err := sql.Open("postgres", dsn)
+	if err != nil {
+		fmt.Println("godb1: connectionOrNil:", err)
+		return nil
+	}
+	return conn
+}
+
+func runWithConnection() {
+	conn := connectionOrNil()
+	if conn == nil {
+		return
+	}
+
+	// Wire the real connection in so InsertReturning below (which goes
+	// through the package-level db) runs against it too, not just the
+	// Do/Row/MustQuery calls above that take conn explicitly.
+	godb1.SetDB(conn)
+	godb1.SetDialect(godb1.PostgresDialect)
+
+	type userRow struct {
+		ID   int
+		Name string
+		Age  int
+	}
+
+	ctx := context.Background()
+	godb1.Do(ctx, conn, func(ctx context.Context, tx *godb1.Tx) error {
+		u, err := godb1.Row[userRow](tx, `SELECT id, name, age FROM users WHERE id = $id`, godb1.Bind("id", 1234))
+		if err != nil {
+			return err
+		}
+		fmt.Println(u)
+
+		// nested Do reuses this same transaction as a SAVEPOINT
+		return godb1.Do(ctx, conn, func(ctx context.Context, tx *godb1.Tx) error {
+			return godb1.MustQuery(tx, `SELECT id, name FROM users WHERE {where}`, godb1.WhereGt("age", 30)).Each(func(r *godb1.Rows) {
+				var id int
+				var name string
+				r.MustScans(&id, &name)
+				fmt.Println(id, name)
+			})
+		})
+	})
+
+	var newID int64
+	godb1.InsertReturning("user", []string{"id"}, []interface{}{&newID},
+		godb1.Field("name", "Dave"),
+		godb1.Field("age", 45),
+	)
+}
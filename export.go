@@ -0,0 +1,170 @@
+package godb1
+
+// This file is the stable, exported surface over the builders defined
+// elsewhere in the package. Everything here is a thin wrapper around
+// an existing lowercase function; the wrapping exists so code outside
+// this package -- notably the code cmd/godb1-gen generates -- can build
+// and run queries without depending on unexported identifiers.
+
+// Bind supplies the value for a "$name" token in a SQL template.
+func Bind(name string, value interface{}) Param {
+	return param(name, value)
+}
+
+// BindSQL is Bind, but sql is substituted for the "$name" token
+// verbatim instead of being bound as a placeholder arg.
+func BindSQL(name string, value interface{}, sql string) Param {
+	return param_sql(name, value, sql)
+}
+
+// Field builds an insert/update column assignment from a literal value.
+func Field(fieldName string, value interface{}) Param {
+	return field(fieldName, value)
+}
+
+// FieldSQL is Field, but sql is used verbatim in {values}/{field=value}
+// instead of binding value as a placeholder arg.
+func FieldSQL(fieldName string, value interface{}, sql string) Param {
+	return field_sql(fieldName, value, sql)
+}
+
+// FieldIfSet is the PatchParam builder behind update()'s PATCH
+// semantics: it only produces a Field when present is true.
+func FieldIfSet(fieldName string, value interface{}, present bool) Param {
+	return field_if_set(fieldName, value, present)
+}
+
+// FieldNull is the PatchParam that explicitly sets a column to NULL.
+func FieldNull(fieldName string) Param {
+	return field_null(fieldName)
+}
+
+// WhereNull matches rows where field IS NULL.
+func WhereNull(field string) Param {
+	return where_null(field)
+}
+
+// WhereNotNull matches rows where field IS NOT NULL.
+func WhereNotNull(field string) Param {
+	return where_not_null(field)
+}
+
+// WhereEq matches rows where field = value.
+func WhereEq(field string, value interface{}) Param {
+	return where_eq(field, value)
+}
+
+// WhereNe matches rows where field != value.
+func WhereNe(field string, value interface{}) Param {
+	return where_ne(field, value)
+}
+
+// WhereGt matches rows where field > value.
+func WhereGt(field string, value interface{}) Param {
+	return where_gt(field, value)
+}
+
+// WhereGte matches rows where field >= value.
+func WhereGte(field string, value interface{}) Param {
+	return where_gte(field, value)
+}
+
+// WhereLt matches rows where field < value.
+func WhereLt(field string, value interface{}) Param {
+	return where_lt(field, value)
+}
+
+// WhereLte matches rows where field <= value.
+func WhereLte(field string, value interface{}) Param {
+	return where_lte(field, value)
+}
+
+// WhereIn matches rows where field is one of values.
+func WhereIn[T any](field string, values []T) Param {
+	return where_in(field, values)
+}
+
+// WhereNotIn matches rows where field is none of values.
+func WhereNotIn[T any](field string, values []T) Param {
+	return where_not_in(field, values)
+}
+
+// WhereBetween matches field BETWEEN lo AND hi.
+func WhereBetween[T any](field string, lo, hi T) Param {
+	return where_between(field, lo, hi)
+}
+
+// WhereLike matches rows where field LIKE pattern.
+func WhereLike(field string, pattern string) Param {
+	return where_like(field, pattern)
+}
+
+// WhereILike matches rows where field ILIKE pattern.
+func WhereILike(field string, pattern string) Param {
+	return where_ilike(field, pattern)
+}
+
+// WhereRaw is the escape hatch for a where clause with no dedicated
+// builder; see where_raw's doc comment for its "?" placeholder rules.
+func WhereRaw(sqlFragment string, values ...interface{}) Param {
+	return where_raw(sqlFragment, values...)
+}
+
+// WhereAny OR-combines clauses.
+func WhereAny(clauses ...Param) Param {
+	return where_any(clauses...)
+}
+
+// WhereAll AND-combines clauses.
+func WhereAll(clauses ...Param) Param {
+	return where_all(clauses...)
+}
+
+// Insert constructs and executes an INSERT against the package-level
+// connection set by SetDB.
+func Insert(table string, params ...Param) {
+	insert(table, params...)
+}
+
+// Update constructs and executes an UPDATE against the package-level
+// connection set by SetDB.
+func Update(table string, params ...Param) {
+	update(table, params...)
+}
+
+// Delete constructs and executes a DELETE against the package-level
+// connection set by SetDB.
+func Delete(table string, params ...Param) {
+	delete(table, params...)
+}
+
+// Execute runs sqlTemplate against the package-level connection set by
+// SetDB.
+func Execute(sqlTemplate string, params ...Param) {
+	execute(sqlTemplate, params...)
+}
+
+// Upsert inserts params, updating conflictCols' row in place if it
+// already exists, against the package-level connection set by SetDB.
+func Upsert(table string, conflictCols []string, params ...Param) {
+	upsert(table, conflictCols, params...)
+}
+
+// InsertMany inserts rows as a single multi-VALUES INSERT against the
+// package-level connection set by SetDB.
+func InsertMany(table string, rows []map[string]interface{}) error {
+	return insert_many(table, rows)
+}
+
+// InsertReturning inserts params and scans the named returning columns
+// back into dest, against the package-level connection set by SetDB.
+func InsertReturning(table string, returning []string, dest []interface{}, params ...Param) error {
+	return insert_returning(table, returning, dest, params...)
+}
+
+// InsertManyReturning is InsertMany plus a scanned-back row of
+// returning values per inserted row, against the package-level
+// connection set by SetDB.
+func InsertManyReturning(table string, returning []string, rows []map[string]interface{}) ([]map[string]interface{}, error) {
+	return insert_many_returning(table, returning, rows)
+}
@@ -0,0 +1,32 @@
+package godb1
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FieldsFromStruct builds Field() params from every exported field of
+// a struct (or pointer to struct), one per field, in struct declaration
+// order. Unlike FieldsFromPatch, every field is always present: this is
+// the shape Insert needs, where the caller supplies a fully-populated
+// row rather than a partial PATCH body.
+func FieldsFromStruct(row interface{}) ([]Param, error) {
+	v := reflect.ValueOf(row)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("godb1: FieldsFromStruct: expected a struct or pointer to struct, got %T", row)
+	}
+	t := v.Type()
+
+	var params []Param
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" { // unexported
+			continue
+		}
+		params = append(params, Field(columnName(sf), v.Field(i).Interface()))
+	}
+	return params, nil
+}
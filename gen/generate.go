@@ -0,0 +1,155 @@
+package gen
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// columnView is one column as generate.go's writers need it.
+type columnView struct {
+	GoName string
+	GoType string
+	DBName string
+}
+
+// tableView is everything generateTable needs to emit one table's file.
+type tableView struct {
+	Package    string
+	Table      string
+	Model      string
+	FuncPrefix string
+	PKColumn   string
+	PKType     string
+	Columns    []columnView
+	Imports    []string
+}
+
+// GenerateTable writes cfg.OutputDir/<table>.go: a Row struct, a Patch
+// struct, and Insert/UpdateByID/Find wrappers over this module's
+// Field/Where*/InsertRowReturning/UpdateRow/RowList primitives.
+func GenerateTable(cfg *Config, table Table) error {
+	if len(table.Columns) == 0 {
+		return fmt.Errorf("table has no columns")
+	}
+	view, err := buildTableView(cfg, table)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by godb1-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&sb, "package %s\n\n", view.Package)
+	sb.WriteString("import (\n\t\"gahooa/godb1\"\n")
+	for _, imp := range view.Imports {
+		fmt.Fprintf(&sb, "\t%q\n", imp)
+	}
+	sb.WriteString(")\n\n")
+
+	writeRowStruct(&sb, view)
+	writePatchStruct(&sb, view)
+	writeInsertFunc(&sb, view)
+	writeUpdateFunc(&sb, view)
+	writeFindFunc(&sb, view)
+
+	formatted, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		return fmt.Errorf("formatting generated code: %w\n%s", err, sb.String())
+	}
+	return os.WriteFile(filepath.Join(cfg.OutputDir, table.Name+".go"), formatted, 0o644)
+}
+
+func buildTableView(cfg *Config, table Table) (tableView, error) {
+	if len(table.PrimaryKey) == 0 {
+		return tableView{}, fmt.Errorf("table %q has no primary key; godb1-gen requires one to generate UpdateByID", table.Name)
+	}
+	if len(table.PrimaryKey) > 1 {
+		return tableView{}, fmt.Errorf("table %q has a composite primary key (%s); godb1-gen only supports single-column primary keys", table.Name, strings.Join(table.PrimaryKey, ", "))
+	}
+	pkColumn := table.PrimaryKey[0]
+
+	model := cfg.modelName(table.Name)
+	view := tableView{
+		Package:    cfg.Package,
+		Table:      table.Name,
+		Model:      model,
+		FuncPrefix: model,
+		PKColumn:   pkColumn,
+	}
+
+	imports := map[string]bool{}
+	pkFound := false
+	for _, col := range table.Columns {
+		goType, imp := cfg.goType(col.DBType)
+		if imp != "" {
+			imports[imp] = true
+		}
+		view.Columns = append(view.Columns, columnView{
+			GoName: toGoName(col.Name),
+			GoType: goType,
+			DBName: col.Name,
+		})
+		if col.Name == pkColumn {
+			pkFound = true
+			view.PKType = goType
+		}
+	}
+	if !pkFound {
+		return tableView{}, fmt.Errorf("table %q: primary key column %q not found among its introspected columns", table.Name, pkColumn)
+	}
+
+	for imp := range imports {
+		view.Imports = append(view.Imports, imp)
+	}
+	sort.Strings(view.Imports)
+	return view, nil
+}
+
+func writeRowStruct(sb *strings.Builder, view tableView) {
+	fmt.Fprintf(sb, "// %sRow is the generated row type for the %q table.\n", view.Model, view.Table)
+	fmt.Fprintf(sb, "type %sRow struct {\n", view.Model)
+	for _, col := range view.Columns {
+		fmt.Fprintf(sb, "\t%s %s `db:\"%s\"`\n", col.GoName, col.GoType, col.DBName)
+	}
+	sb.WriteString("}\n\n")
+}
+
+func writePatchStruct(sb *strings.Builder, view tableView) {
+	fmt.Fprintf(sb, "// %sPatch is the generated PATCH type for the %q table: a nil field\n// means \"leave this column untouched\".\n", view.Model, view.Table)
+	fmt.Fprintf(sb, "type %sPatch struct {\n", view.Model)
+	for _, col := range view.Columns {
+		if col.DBName == view.PKColumn {
+			continue
+		}
+		fmt.Fprintf(sb, "\t%s *%s `json:\"%s\"`\n", col.GoName, col.GoType, col.DBName)
+	}
+	sb.WriteString("}\n\n")
+}
+
+func writeInsertFunc(sb *strings.Builder, view tableView) {
+	fmt.Fprintf(sb, "// %sInsert inserts row into %q and returns its %s.\n", view.FuncPrefix, view.Table, view.PKColumn)
+	fmt.Fprintf(sb, "func %sInsert(db godb1.DBTX, row %sRow) (%s, error) {\n", view.FuncPrefix, view.Model, view.PKType)
+	fmt.Fprintf(sb, "\tvar zero %s\n", view.PKType)
+	sb.WriteString("\tfields, err := godb1.FieldsFromStruct(row)\n\tif err != nil {\n\t\treturn zero, err\n\t}\n")
+	fmt.Fprintf(sb, "\tvar insertFields []godb1.Param\n\tfor _, f := range fields {\n\t\tif f.Field == %q {\n\t\t\tcontinue\n\t\t}\n\t\tinsertFields = append(insertFields, f)\n\t}\n", view.PKColumn)
+	fmt.Fprintf(sb, "\tvar id %s\n", view.PKType)
+	fmt.Fprintf(sb, "\tif err := godb1.InsertRowReturning(db, %q, []string{%q}, []interface{}{&id}, insertFields...); err != nil {\n\t\treturn zero, err\n\t}\n", view.Table, view.PKColumn)
+	sb.WriteString("\treturn id, nil\n}\n\n")
+}
+
+func writeUpdateFunc(sb *strings.Builder, view tableView) {
+	fmt.Fprintf(sb, "// %sUpdateByID patches the row in %q identified by %s.\n", view.FuncPrefix, view.Table, view.PKColumn)
+	fmt.Fprintf(sb, "func %sUpdateByID(db godb1.DBTX, id %s, patch %sPatch) error {\n", view.FuncPrefix, view.PKType, view.Model)
+	sb.WriteString("\tfields, err := godb1.FieldsFromPatch(&patch)\n\tif err != nil {\n\t\treturn err\n\t}\n")
+	fmt.Fprintf(sb, "\tparams := append(fields, godb1.WhereEq(%q, id))\n", view.PKColumn)
+	fmt.Fprintf(sb, "\treturn godb1.UpdateRow(db, %q, params...)\n}\n\n", view.Table)
+}
+
+func writeFindFunc(sb *strings.Builder, view tableView) {
+	fmt.Fprintf(sb, "// %sFind returns every row in %q matching where.\n", view.FuncPrefix, view.Table)
+	fmt.Fprintf(sb, "func %sFind(db godb1.DBTX, where ...godb1.Param) ([]%sRow, error) {\n", view.FuncPrefix, view.Model)
+	fmt.Fprintf(sb, "\treturn godb1.FindRows[%sRow](db, %q, where...)\n}\n", view.Model, view.Table)
+}
@@ -0,0 +1,36 @@
+package gen
+
+import "strings"
+
+// toGoName converts a snake_case database identifier into a PascalCase
+// Go identifier ("user_id" -> "UserID" is NOT special-cased; it comes
+// out as "UserId" -- initialisms are left to Config.NameOverrides).
+func toGoName(name string) string {
+	parts := strings.Split(name, "_")
+	var sb strings.Builder
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		sb.WriteString(strings.ToUpper(p[:1]))
+		sb.WriteString(p[1:])
+	}
+	return sb.String()
+}
+
+// singularize strips a table name's trailing plural so it can be used
+// as a struct name ("users" -> "user", "categories" -> "category"). It
+// is a best-effort heuristic, not a full English singularizer;
+// irregular plurals (e.g. "people") should use Config.NameOverrides.
+func singularize(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "ses"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	default:
+		return name
+	}
+}
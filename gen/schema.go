@@ -0,0 +1,129 @@
+package gen
+
+import (
+	"database/sql"
+	"fmt"
+
+	"gahooa/godb1"
+)
+
+// Column is one introspected database column.
+type Column struct {
+	Name     string
+	DBType   string
+	Nullable bool
+}
+
+// Table is one introspected base table, its columns in ordinal position
+// order, and its primary key column names in ordinal position within
+// the key (not necessarily the same order as Columns).
+type Table struct {
+	Name       string
+	Columns    []Column
+	PrimaryKey []string
+}
+
+// Introspect lists every base table (and its columns) visible to db,
+// via information_schema -- the portable subset Postgres, MySQL,
+// CockroachDB and SQLite (with its information_schema extension) all
+// expose the same way.
+func Introspect(db *sql.DB, d godb1.Dialect) ([]Table, error) {
+	rows, err := db.Query(`
+		SELECT table_name
+		FROM information_schema.tables
+		WHERE table_type = 'BASE TABLE'
+			AND table_schema NOT IN ('information_schema', 'pg_catalog')
+		ORDER BY table_name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	tables := make([]Table, 0, len(names))
+	for _, name := range names {
+		cols, err := introspectColumns(db, d, name)
+		if err != nil {
+			return nil, fmt.Errorf("table %q: %w", name, err)
+		}
+		pk, err := introspectPrimaryKey(db, d, name)
+		if err != nil {
+			return nil, fmt.Errorf("table %q: %w", name, err)
+		}
+		tables = append(tables, Table{Name: name, Columns: cols, PrimaryKey: pk})
+	}
+	return tables, nil
+}
+
+func introspectColumns(db *sql.DB, d godb1.Dialect, table string) ([]Column, error) {
+	query := fmt.Sprintf(`
+		SELECT column_name, data_type, is_nullable
+		FROM information_schema.columns
+		WHERE table_name = %s
+		ORDER BY ordinal_position
+	`, d.Placeholder(1))
+
+	rows, err := db.Query(query, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cols []Column
+	for rows.Next() {
+		var name, dataType, isNullable string
+		if err := rows.Scan(&name, &dataType, &isNullable); err != nil {
+			return nil, err
+		}
+		cols = append(cols, Column{Name: name, DBType: dataType, Nullable: isNullable == "YES"})
+	}
+	return cols, rows.Err()
+}
+
+// introspectPrimaryKey returns table's primary key column names, in
+// their ordinal position within the key, by joining
+// information_schema.table_constraints to key_column_usage -- the
+// portable way to find a PRIMARY KEY across Postgres, MySQL,
+// CockroachDB and SQLite's information_schema extension. A table with
+// no primary key returns (nil, nil).
+func introspectPrimaryKey(db *sql.DB, d godb1.Dialect, table string) ([]string, error) {
+	query := fmt.Sprintf(`
+		SELECT kcu.column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_name = tc.constraint_name
+			AND kcu.table_schema = tc.table_schema
+			AND kcu.table_name = tc.table_name
+		WHERE tc.table_name = %s
+			AND tc.constraint_type = 'PRIMARY KEY'
+		ORDER BY kcu.ordinal_position
+	`, d.Placeholder(1))
+
+	rows, err := db.Query(query, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pk []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		pk = append(pk, name)
+	}
+	return pk, rows.Err()
+}
@@ -0,0 +1,153 @@
+package godb1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// insert_many inserts rows as a single multi-VALUES INSERT, chunked so
+// each statement stays under the dialect's MaxParams. Every map must
+// have the same set of keys as rows[0]; the column order is that set,
+// sorted, so repeated calls with the same shape produce identical SQL
+// and reuse the same cached prepared statement.
+func insert_many(table string, rows []map[string]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	cols := make([]string, 0, len(rows[0]))
+	for col := range rows[0] {
+		cols = append(cols, col)
+	}
+	if len(cols) == 0 {
+		return fmt.Errorf("godb1: insert_many: rows[0] has no columns")
+	}
+	sort.Strings(cols)
+
+	maxParams := defaultDialect.MaxParams
+	if maxParams <= 0 {
+		maxParams = 65535
+	}
+	chunkSize := maxParams / len(cols)
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+
+	for start := 0; start < len(rows); start += chunkSize {
+		end := start + chunkSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		if err := insertManyChunk(table, cols, rows[start:end]); err != nil {
+			return fmt.Errorf("godb1: insert_many: rows %d-%d: %w", start, end-1, err)
+		}
+	}
+	return nil
+}
+
+func insertManyChunk(table string, cols []string, rows []map[string]interface{}) error {
+	finalSQL, args, err := buildMultiValuesInsert(table, cols, rows)
+	if err != nil {
+		return err
+	}
+	return runExec(finalSQL, args)
+}
+
+// buildMultiValuesInsert renders "INSERT INTO t (a, b) VALUES (?, ?), (?, ?)"
+// plus the flattened, row-major args.
+func buildMultiValuesInsert(table string, cols []string, rows []map[string]interface{}) (string, []interface{}, error) {
+	var sb strings.Builder
+	sb.WriteString("INSERT INTO ")
+	sb.WriteString(quote_ident(table))
+	sb.WriteString(" (")
+	for i, col := range cols {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(quote_ident(col))
+	}
+	sb.WriteString(") VALUES ")
+
+	args := make([]interface{}, 0, len(cols)*len(rows))
+	for ri, row := range rows {
+		if ri > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(")
+		for ci, col := range cols {
+			if ci > 0 {
+				sb.WriteString(", ")
+			}
+			v, ok := row[col]
+			if !ok {
+				return "", nil, fmt.Errorf("row %d is missing column %q (every row must share rows[0]'s columns)", ri, col)
+			}
+			args = append(args, v)
+			sb.WriteString(defaultDialect.Placeholder(len(args)))
+		}
+		sb.WriteString(")")
+	}
+	return sb.String(), args, nil
+}
+
+// runExec executes finalSQL/args through the shared prepared-statement
+// cache, or prints it when no db is configured, matching execute()'s
+// fallback behavior.
+func runExec(finalSQL string, args []interface{}) error {
+	if db == nil {
+		print_resolved(finalSQL, args)
+		return nil
+	}
+	stmt, err := globalStmtCache.prepare(db, finalSQL)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(args...)
+	return err
+}
+
+// upsert inserts params, updating conflictCols' row in place if it
+// already exists. On Postgres/CockroachDB/SQLite this is
+// "ON CONFLICT (...) DO UPDATE SET ..."; on MySQL it is
+// "ON DUPLICATE KEY UPDATE ...", which infers the conflicting key from
+// the table's own unique/primary key rather than conflictCols.
+func upsert(table string, conflictCols []string, params ...Param) {
+	sqlTemplate := fmt.Sprintf("INSERT INTO %s ({fields}) VALUES ({values}) %s",
+		quote_ident(table), upsertClause(conflictCols, params))
+	execute(sqlTemplate, params...)
+}
+
+func upsertClause(conflictCols []string, params []Param) string {
+	conflictSet := make(map[string]bool, len(conflictCols))
+	for _, c := range conflictCols {
+		conflictSet[c] = true
+	}
+
+	var setCols []string
+	for _, p := range params {
+		if (p.Type == "field" || p.Type == "field_sql") && !conflictSet[p.Field] {
+			setCols = append(setCols, p.Field)
+		}
+	}
+
+	if defaultDialect.Name == "mysql" {
+		parts := make([]string, len(setCols))
+		for i, c := range setCols {
+			ident := quote_ident(c)
+			parts[i] = fmt.Sprintf("%s = VALUES(%s)", ident, ident)
+		}
+		return "ON DUPLICATE KEY UPDATE " + strings.Join(parts, ", ")
+	}
+
+	quotedConflict := make([]string, len(conflictCols))
+	for i, c := range conflictCols {
+		quotedConflict[i] = quote_ident(c)
+	}
+	parts := make([]string, len(setCols))
+	for i, c := range setCols {
+		ident := quote_ident(c)
+		parts[i] = fmt.Sprintf("%s = EXCLUDED.%s", ident, ident)
+	}
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(quotedConflict, ", "), strings.Join(parts, ", "))
+}
@@ -0,0 +1,73 @@
+package godb1
+
+import "testing"
+
+func withDialect(t *testing.T, d Dialect, fn func()) {
+	t.Helper()
+	prev := defaultDialect
+	SetDialect(d)
+	defer SetDialect(prev)
+	fn()
+}
+
+func TestBuildMultiValuesInsert(t *testing.T) {
+	withDialect(t, MySQLDialect, func() {
+		sqlStr, args, err := buildMultiValuesInsert("user", []string{"age", "name"}, []map[string]interface{}{
+			{"name": "Alice", "age": 31},
+			{"name": "Bob", "age": 28},
+		})
+		if err != nil {
+			t.Fatalf("buildMultiValuesInsert: %v", err)
+		}
+		wantSQL := "INSERT INTO `user` (`age`, `name`) VALUES (?, ?), (?, ?)"
+		if sqlStr != wantSQL {
+			t.Errorf("sql = %q, want %q", sqlStr, wantSQL)
+		}
+		wantArgs := []interface{}{31, "Alice", 28, "Bob"}
+		if len(args) != len(wantArgs) {
+			t.Fatalf("args = %v, want %v", args, wantArgs)
+		}
+		for i := range args {
+			if args[i] != wantArgs[i] {
+				t.Errorf("args[%d] = %v, want %v", i, args[i], wantArgs[i])
+			}
+		}
+	})
+}
+
+func TestBuildMultiValuesInsertMissingColumnIsAnError(t *testing.T) {
+	withDialect(t, MySQLDialect, func() {
+		_, _, err := buildMultiValuesInsert("user", []string{"age", "name"}, []map[string]interface{}{
+			{"name": "Alice"},
+		})
+		if err == nil {
+			t.Fatal("buildMultiValuesInsert: want error for row missing a column, got nil")
+		}
+	})
+}
+
+func TestUpsertClauseMySQLUsesValuesFunction(t *testing.T) {
+	withDialect(t, MySQLDialect, func() {
+		clause := upsertClause([]string{"name"}, []Param{
+			Field("name", "Alice"),
+			Field("age", 32),
+		})
+		want := "ON DUPLICATE KEY UPDATE `age` = VALUES(`age`)"
+		if clause != want {
+			t.Errorf("clause = %q, want %q", clause, want)
+		}
+	})
+}
+
+func TestUpsertClausePostgresUsesExcluded(t *testing.T) {
+	withDialect(t, PostgresDialect, func() {
+		clause := upsertClause([]string{"name"}, []Param{
+			Field("name", "Alice"),
+			Field("age", 32),
+		})
+		want := `ON CONFLICT ("name") DO UPDATE SET "age" = EXCLUDED."age"`
+		if clause != want {
+			t.Errorf("clause = %q, want %q", clause, want)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package godb1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// insert_returning inserts params and scans the named returning columns
+// back into dest (pointers, in the same order as returning). On dialects
+// without RETURNING (MySQL), returning must name exactly the table's
+// auto-increment column and dest[0] must be *int64 or *int.
+func insert_returning(table string, returning []string, dest []interface{}, params ...Param) error {
+	if len(returning) != len(dest) {
+		return fmt.Errorf("godb1: insert_returning: %d returning columns but %d dest pointers", len(returning), len(dest))
+	}
+
+	sqlTemplate := fmt.Sprintf("INSERT INTO %s ({fields}) VALUES ({values})", quote_ident(table))
+	finalSQL, args, err := resolve(sqlTemplate, params)
+	if err != nil {
+		return err
+	}
+
+	if !defaultDialect.SupportsReturning {
+		if len(returning) != 1 {
+			return fmt.Errorf("godb1: insert_returning: dialect %q has no RETURNING, only the single auto-increment id can be returned", defaultDialect.Name)
+		}
+		if db == nil {
+			print_resolved(finalSQL, args)
+			return nil
+		}
+		stmt, err := globalStmtCache.prepare(db, finalSQL)
+		if err != nil {
+			return err
+		}
+		result, err := stmt.Exec(args...)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		return assignInt64(dest[0], id)
+	}
+
+	quoted := make([]string, len(returning))
+	for i, col := range returning {
+		quoted[i] = quote_ident(col)
+	}
+	finalSQL += " RETURNING " + strings.Join(quoted, ", ")
+
+	if db == nil {
+		print_resolved(finalSQL, args)
+		return nil
+	}
+	stmt, err := globalStmtCache.prepare(db, finalSQL)
+	if err != nil {
+		return err
+	}
+	return stmt.QueryRow(args...).Scan(dest...)
+}
+
+// insert_many_returning is insert_many plus a scanned-back row of
+// returning values per inserted row, in insertion order. On MySQL
+// (no RETURNING) returning must be a single auto-increment column;
+// AUTO_INCREMENT ids are contiguous within one INSERT, so the rest are
+// derived from the first id returned by the driver.
+func insert_many_returning(table string, returning []string, rows []map[string]interface{}) ([]map[string]interface{}, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	cols := make([]string, 0, len(rows[0]))
+	for col := range rows[0] {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+
+	finalSQL, args, err := buildMultiValuesInsert(table, cols, rows)
+	if err != nil {
+		return nil, fmt.Errorf("godb1: insert_many_returning: %w", err)
+	}
+
+	if !defaultDialect.SupportsReturning {
+		if len(returning) != 1 {
+			return nil, fmt.Errorf("godb1: insert_many_returning: dialect %q has no RETURNING, only the single auto-increment id can be returned", defaultDialect.Name)
+		}
+		if db == nil {
+			print_resolved(finalSQL, args)
+			return nil, nil
+		}
+		stmt, err := globalStmtCache.prepare(db, finalSQL)
+		if err != nil {
+			return nil, err
+		}
+		result, err := stmt.Exec(args...)
+		if err != nil {
+			return nil, err
+		}
+		firstID, err := result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		out := make([]map[string]interface{}, len(rows))
+		for i := range rows {
+			out[i] = map[string]interface{}{returning[0]: firstID + int64(i)}
+		}
+		return out, nil
+	}
+
+	quoted := make([]string, len(returning))
+	for i, col := range returning {
+		quoted[i] = quote_ident(col)
+	}
+	finalSQL += " RETURNING " + strings.Join(quoted, ", ")
+
+	if db == nil {
+		print_resolved(finalSQL, args)
+		return nil, nil
+	}
+	stmt, err := globalStmtCache.prepare(db, finalSQL)
+	if err != nil {
+		return nil, err
+	}
+	dbRows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer dbRows.Close()
+
+	var out []map[string]interface{}
+	for dbRows.Next() {
+		scanArgs := make([]interface{}, len(returning))
+		values := make([]interface{}, len(returning))
+		for i := range values {
+			scanArgs[i] = &values[i]
+		}
+		if err := dbRows.Scan(scanArgs...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]interface{}, len(returning))
+		for i, col := range returning {
+			row[col] = values[i]
+		}
+		out = append(out, row)
+	}
+	return out, dbRows.Err()
+}
+
+func assignInt64(dest interface{}, id int64) error {
+	switch p := dest.(type) {
+	case *int64:
+		*p = id
+		return nil
+	case *int:
+		*p = int(id)
+		return nil
+	default:
+		return fmt.Errorf("godb1: insert_returning: dest must be *int64 or *int, got %T", dest)
+	}
+}
@@ -0,0 +1,32 @@
+package godb1
+
+import "testing"
+
+func TestAssignInt64(t *testing.T) {
+	t.Run("int64 dest", func(t *testing.T) {
+		var dest int64
+		if err := assignInt64(&dest, 42); err != nil {
+			t.Fatalf("assignInt64: %v", err)
+		}
+		if dest != 42 {
+			t.Errorf("dest = %d, want 42", dest)
+		}
+	})
+
+	t.Run("int dest", func(t *testing.T) {
+		var dest int
+		if err := assignInt64(&dest, 42); err != nil {
+			t.Fatalf("assignInt64: %v", err)
+		}
+		if dest != 42 {
+			t.Errorf("dest = %d, want 42", dest)
+		}
+	})
+
+	t.Run("unsupported dest type is an error", func(t *testing.T) {
+		var dest string
+		if err := assignInt64(&dest, 42); err == nil {
+			t.Fatal("assignInt64: want error for *string dest, got nil")
+		}
+	})
+}
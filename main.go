@@ -1,11 +1,6 @@
-package main
-
-import (
-	"fmt"
-)
-
-
 /*
+Package godb1 keeps developers thinking in SQL.
+
 The features of this library are:
 1. keeps development team thinking in SQL
 2. 100% safe -- never need SQL injection 
@@ -42,174 +37,79 @@ Implemenation:
 - I can provide more detail, but this is the essential idea.
 
 */
+package godb1
+
+import (
+	"database/sql"
+	"fmt"
+)
 
-func main() {
-
-    // Get a single value.  If it is not found, that is an error.
-	value(`SELECT create_date FROM users WHERE id = $id`, param("id", 1234))
-
-    // Get a single value, if it is not found, return nil
-    value_nil(`SELECT create_date FROM users WHERE id = $id`, param("id", 12345))
-    
-    // get a list of statuses
-    value_list(`SELECT status FROM statuses WHERE pod_id = $id`, param("id", 1234))
-
-    // Get a single row.  If it is not found, that is an error.
-    // take note of the use of $id
-	row(`
-        SELECT
-            id,
-            name,
-            age,
-        FROM 
-            users 
-        WHERE true
-            AND id = $id
-        `,
-		param("id", 1234),
-	)
-
-    // Get a single row, if it is not found, return nil
-    row_nil(
-        "SELECT id, name, age FROM users WHERE id = $id AND active = $active", 
-        param("id", 12345), 
-        param("active", true),
-    )
-
-    // Take advantage of the {where} automation
-    row_list(`
-        SELECT
-            id,
-            name,
-            age,
-        FROM 
-            users   
-        WHERE True
-            AND NOT deleted
-            AND {where}
-        `,
-        where_gt("age", 30),
-        where_lt("age", 40),
-    )
-
-    // create a dynamic list of filters to pass along
-    filters := []Param{};
-    filters = append(filters, where_gt("age", 30))
-    filters = append(filters, where_lt("age", 40))
-	row_list(`SELECT id, name, age FROM users WHERE {where}`,filters...)
-
-    // Running a complex update with automation for {fields=values} and {where}, as well as params
-    execute(`
-        UPDATE
-            pod
-        SET 
-            {field=value}
-        WHERE true
-            AND pod_cat_id = $pod_cat_id
-            AND pod_id = (select max(pod_id) from pod_network WHERE category = $category)
-            AND NOT deleted
-            AND {where}
-        `,
-        field("active", true),
-        field("name", "New Name"),
-        field_sql("age", 30, "$age + 1"),
-        param("pod_cat_id", 12345),
-        param("category", "test"),
-        where_not_null("error_message"),
-    )
-
-    // automatic insert construction
-	insert("user", 
-        param("name", "Jason"), 
-        param("age", 30),
-    )
-
-    // example where we actually just pass actual sql to the update
-	update("user", 
-        param("name", "New Name"), 
-        param_sql("age", nil, "age + 1"),
-        where_eq("id", 12345),
-        where_eq("active", true),
-    )
-
-    // delete a record
-    delete("user", param("id", 12345))
+// db is the connection used by execute/insert/update/delete. It is nil
+// until SetDB is called, in which case those functions fall back to
+// printing the resolved SQL and args so the package stays usable
+// without a live database.
+var db *sql.DB
 
+// SetDB wires execute/insert/update/delete to run against a real
+// connection instead of printing.
+func SetDB(conn *sql.DB) {
+	db = conn
 }
 
 ///////////////////////////////////////////////////////////////////////////////////////
 
 // running SQL that doesn't return a value
-func execute(sql string, params ...Param) {
-	// print out the sql string
-	print_params(sql, params)
-}
-
-// getting exactly 1 value, otherwise an error
-func value(sql string, params ...Param) {
-	// print out the sql string
-	print_params(sql, params)
-}
-
-// getting one value, if not found, nil
-func value_nil(sql string, params ...Param) {
-    // print out the sql string
-    print_params(sql, params)
-}
-
-// getting a list or array of values
-func value_list(sql string, params ...Param) {
-	print_params(sql, params)
-}
-
-// getting a single row from the database, or an error if it is not found
-func row(sql string, params ...Param) {
-	// print out the sql string
-	print_params(sql, params)
-}
-
-// getting a single row from the database, or nil if it is not found
-func row_nil(sql string, params ...Param) {
-    // print out the sql string
-    print_params(sql, params)
-}
-
-// getting a list of rows from the database
-func row_list(sql string, params ...Param) {
-	print_params(sql, params)
+func execute(sqlTemplate string, params ...Param) {
+	finalSQL, args, err := resolve(sqlTemplate, params)
+	if err != nil {
+		fmt.Println("godb1: execute error:", err)
+		return
+	}
+	if err := runExec(finalSQL, args); err != nil {
+		fmt.Println("godb1: execute error:", err)
+	}
 }
 
 // constructing and executing an insert statement
 // ideally, we should only accept params of type=field at compile time (maybe a different struct?)
 func insert(table string, params ...Param) {
-	// print out the sql string
-	sql := fmt.Sprintf("INSERT INTO %s  ({fields}) VALUES ({values})", quote_ident(table))
-	execute(sql, params...)
+	sqlTemplate := fmt.Sprintf("INSERT INTO %s  ({fields}) VALUES ({values})", quote_ident(table))
+	execute(sqlTemplate, params...)
 }
 
 // construct and execute an update statement
 // ideally, we should only accept params of type=field or type=where at compile time (maybe a different struct?)
 func update(table string, params ...Param) {
-	// print out the sql string
-	sql := fmt.Sprintf("UPDATE %s SET {fields=values} WHERE {where}", quote_ident(table))
-	execute(sql, params...)
+	sqlTemplate := fmt.Sprintf("UPDATE %s SET {field=value} WHERE {where}", quote_ident(table))
+	execute(sqlTemplate, params...)
 }
 
 // construct and execute a delete statement
 // ideally, we should only accept params of type=where at compile time (maybe a different struct?)
 func delete(table string, params ...Param) {
-	// print out the sql string
-	sql := fmt.Sprintf("DELETE FROM %s WHERE {where}", quote_ident(table))
-	execute(sql, params...)
+	sqlTemplate := fmt.Sprintf("DELETE FROM %s WHERE {where}", quote_ident(table))
+	execute(sqlTemplate, params...)
 }
 
-///////////////////////////////////////////////////////////////////////////////////////
+// resolve runs sqlTemplate and params through the parser for the
+// currently configured dialect.
+func resolve(sqlTemplate string, params []Param) (string, []interface{}, error) {
+	return parse(sqlTemplate, params, defaultDialect)
+}
 
-// need a function to properly quote field or table names (this is not it)
-func quote_ident(name string) string {
-	return "`" + name + "`"
+// print_resolved prints a parsed template the way the original
+// prototype printed its raw param dump, now showing the SQL actually
+// sent to the driver.
+func print_resolved(finalSQL string, args []interface{}) {
+	fmt.Println("-------------------------------------------")
+	fmt.Printf("%s\n", finalSQL)
+	for i, arg := range args {
+		fmt.Printf("  $%d = %v\n", i+1, arg)
+	}
 }
 
+///////////////////////////////////////////////////////////////////////////////////////
+
 // / In rust I would use an enum to differentiate between the different types of params
 // / Not sure best way in go, but this can either be a field value, or a where condition
 type Param struct {
@@ -217,6 +117,13 @@ type Param struct {
 	Field string
 	Value interface{}
 	Sql   string
+
+	// Values holds multiple bound values for params that need more
+	// than one (where_in, where_between, where_raw's positional args).
+	Values []interface{}
+
+	// Children holds the sub-clauses of a where_any/where_all combinator.
+	Children []Param
 }
 
 func param(field string, value interface{}) Param {
@@ -270,51 +177,3 @@ func where_lte(field string, value interface{}) Param {
 }
 
 
-func print_params(sql string, params []Param) {
-	fmt.Println("-------------------------------------------")
-
-	// print the sql string
-	fmt.Printf("%s\n", sql)
-
-    // Print out the fields
-    for _, param := range params {
-        switch param.Type {
-            case "field":
-                fmt.Printf("  %s = %v\n", param.Field, param.Value)
-            case "field_sql":
-                fmt.Printf("  %s = %s\n", param.Field, param.Sql)
-        }
-    }
-
-	// print out the params
-	for _, param := range params {
-		switch param.Type {
-		case "param":
-			fmt.Printf("  %s = %v\n", param.Field, param.Value)
-		case "param_sql":
-			fmt.Printf("  %s = %s\n", param.Field, param.Sql)
-		}
-	}
-
-	for _, param := range params {
-		switch param.Type {
-		case "where_null":
-			fmt.Printf("  WHERE %s IS NULL\n", param.Field)
-		case "where_not_null":
-			fmt.Printf("  WHERE %s IS NOT NULL\n", param.Field)
-		case "where_eq":
-			fmt.Printf("  WHERE %s = %v\n", param.Field, param.Value)
-		case "where_ne":
-			fmt.Printf("  WHERE %s != %v\n", param.Field, param.Value)
-		case "where_gt":
-			fmt.Printf("  WHERE %s > %v\n", param.Field, param.Value)
-		case "where_gte":
-			fmt.Printf("  WHERE %s >= %v\n", param.Field, param.Value)
-		case "where_lt":
-			fmt.Printf("  WHERE %s < %v\n", param.Field, param.Value)
-		case "where_lte":
-			fmt.Printf("  WHERE %s <= %v\n", param.Field, param.Value)
-		}
-	}
-
-}
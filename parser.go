@@ -0,0 +1,188 @@
+package godb1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// tokenPattern matches a "$name" placeholder or one of the four macros,
+// in the order they occur in the template.
+var tokenPattern = regexp.MustCompile(`\$[A-Za-z_][A-Za-z0-9_]*|\{where\}|\{fields\}|\{values\}|\{field=value\}`)
+
+// parse splits a SQL template into literal/placeholder chunks, resolves
+// every "$name" token and every {where}/{fields}/{values}/{field=value}
+// macro against params (in the order they occur in sql), and produces
+// the final SQL string plus the positional args to bind alongside it.
+//
+// {where} is only allowed to consume where_* params. {fields}, {values}
+// and {field=value} are only allowed to consume field/field_sql params.
+// It is an error for a $name token to have no matching param, and it is
+// an error for a param to go entirely unused.
+func parse(sql string, params []Param, d Dialect) (finalSQL string, args []interface{}, err error) {
+	named := map[string]Param{}
+	namedUsed := map[string]bool{}
+	var fieldParams []Param
+	var whereParams []Param
+
+	for _, p := range params {
+		switch p.Type {
+		case "param", "param_sql":
+			if _, exists := named[p.Field]; exists {
+				return "", nil, fmt.Errorf("godb1: duplicate param $%s", p.Field)
+			}
+			named[p.Field] = p
+		case "field", "field_sql", "field_null":
+			fieldParams = append(fieldParams, p)
+		case "field_absent":
+			// a PatchParam for a field the caller didn't send; contributes
+			// nothing to {fields}/{values}/{field=value}.
+		default:
+			if strings.HasPrefix(p.Type, "where") {
+				whereParams = append(whereParams, p)
+			} else {
+				return "", nil, fmt.Errorf("godb1: unknown param type %q", p.Type)
+			}
+		}
+	}
+
+	fieldsUsed := false
+	whereUsed := false
+
+	var out strings.Builder
+	pos := 0
+	for _, loc := range tokenPattern.FindAllStringIndex(sql, -1) {
+		out.WriteString(sql[pos:loc[0]])
+		token := sql[loc[0]:loc[1]]
+		pos = loc[1]
+
+		switch {
+		case strings.HasPrefix(token, "$"):
+			name := token[1:]
+			p, ok := named[name]
+			if !ok {
+				return "", nil, fmt.Errorf("godb1: no param supplied for %s", token)
+			}
+			namedUsed[name] = true
+			if p.Type == "param_sql" {
+				out.WriteString(p.Sql)
+			} else {
+				args = append(args, p.Value)
+				out.WriteString(d.Placeholder(len(args)))
+			}
+
+		case token == "{where}":
+			whereUsed = true
+			clause, clauseArgs, err := renderWhere(whereParams, d, len(args))
+			if err != nil {
+				return "", nil, err
+			}
+			out.WriteString(clause)
+			args = append(args, clauseArgs...)
+
+		case token == "{fields}":
+			if len(fieldParams) == 0 {
+				return "", nil, fmt.Errorf("godb1: %s has no field params to render (every PatchParam was absent?)", token)
+			}
+			fieldsUsed = true
+			out.WriteString(renderFieldNames(fieldParams, d))
+
+		case token == "{values}":
+			if len(fieldParams) == 0 {
+				return "", nil, fmt.Errorf("godb1: %s has no field params to render (every PatchParam was absent?)", token)
+			}
+			fieldsUsed = true
+			clause, clauseArgs := renderFieldValues(fieldParams, d, len(args))
+			out.WriteString(clause)
+			args = append(args, clauseArgs...)
+
+		case token == "{field=value}":
+			if len(fieldParams) == 0 {
+				return "", nil, fmt.Errorf("godb1: %s has no field params to render (every PatchParam was absent?)", token)
+			}
+			fieldsUsed = true
+			clause, clauseArgs := renderFieldAssignments(fieldParams, d, len(args))
+			out.WriteString(clause)
+			args = append(args, clauseArgs...)
+		}
+	}
+	out.WriteString(sql[pos:])
+
+	for name := range named {
+		if !namedUsed[name] {
+			return "", nil, fmt.Errorf("godb1: param $%s was supplied but never referenced", name)
+		}
+	}
+	if len(fieldParams) > 0 && !fieldsUsed {
+		return "", nil, fmt.Errorf("godb1: field param %q was supplied but the template has no {fields}, {values} or {field=value}", fieldParams[0].Field)
+	}
+	if len(whereParams) > 0 && !whereUsed {
+		return "", nil, fmt.Errorf("godb1: where param %q was supplied but the template has no {where}", whereParams[0].Field)
+	}
+
+	return out.String(), args, nil
+}
+
+// renderWhere joins a top-level list of where_* params with AND. Each
+// param (including combinators like where_any/where_all) is rendered
+// by renderWhereParam in where.go.
+func renderWhere(params []Param, d Dialect, argOffset int) (string, []interface{}, error) {
+	if len(params) == 0 {
+		return "1=1", nil, nil
+	}
+
+	var clauses []string
+	var args []interface{}
+	for _, p := range params {
+		clause, clauseArgs, err := renderWhereParam(p, d, argOffset+len(args))
+		if err != nil {
+			return "", nil, err
+		}
+		clauses = append(clauses, clause)
+		args = append(args, clauseArgs...)
+	}
+	return "(" + strings.Join(clauses, " AND ") + ")", args, nil
+}
+
+func renderFieldNames(params []Param, d Dialect) string {
+	names := make([]string, len(params))
+	for i, p := range params {
+		names[i] = d.QuoteIdent(p.Field)
+	}
+	return strings.Join(names, ", ")
+}
+
+func renderFieldValues(params []Param, d Dialect, argOffset int) (string, []interface{}) {
+	var parts []string
+	var args []interface{}
+	for _, p := range params {
+		switch p.Type {
+		case "field_sql":
+			parts = append(parts, p.Sql)
+		case "field_null":
+			parts = append(parts, "NULL")
+		default:
+			args = append(args, p.Value)
+			parts = append(parts, d.Placeholder(argOffset+len(args)))
+		}
+	}
+	return strings.Join(parts, ", "), args
+}
+
+func renderFieldAssignments(params []Param, d Dialect, argOffset int) (string, []interface{}) {
+	var parts []string
+	var args []interface{}
+	for _, p := range params {
+		ident := d.QuoteIdent(p.Field)
+		switch p.Type {
+		case "field_sql":
+			parts = append(parts, fmt.Sprintf("%s = %s", ident, p.Sql))
+		case "field_null":
+			parts = append(parts, fmt.Sprintf("%s = NULL", ident))
+		default:
+			args = append(args, p.Value)
+			parts = append(parts, fmt.Sprintf("%s = %s", ident, d.Placeholder(argOffset+len(args))))
+		}
+	}
+	return strings.Join(parts, ", "), args
+}
@@ -0,0 +1,145 @@
+package godb1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNamedParams(t *testing.T) {
+	cases := []struct {
+		name     string
+		sql      string
+		params   []Param
+		wantSQL  string
+		wantArgs []interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "single named param",
+			sql:      "SELECT id FROM users WHERE id = $id",
+			params:   []Param{param("id", 1234)},
+			wantSQL:  "SELECT id FROM users WHERE id = ?",
+			wantArgs: []interface{}{1234},
+		},
+		{
+			name:     "repeated token binds once per occurrence, in order",
+			sql:      "SELECT $id, $name, $id",
+			params:   []Param{param("id", 1), param("name", "a")},
+			wantSQL:  "SELECT ?, ?, ?",
+			wantArgs: []interface{}{1, "a", 1},
+		},
+		{
+			name:    "missing param for token is an error",
+			sql:     "SELECT * FROM users WHERE id = $id",
+			params:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "supplied param never referenced is an error",
+			sql:     "SELECT 1",
+			params:  []Param{param("id", 1)},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate param name is an error",
+			sql:     "SELECT $id",
+			params:  []Param{param("id", 1), param("id", 2)},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := parse(c.sql, c.params, MySQLDialect)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("parse(%q) = nil error, want error", c.sql)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse(%q) unexpected error: %v", c.sql, err)
+			}
+			if gotSQL != c.wantSQL {
+				t.Errorf("sql = %q, want %q", gotSQL, c.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, c.wantArgs) {
+				t.Errorf("args = %v, want %v", gotArgs, c.wantArgs)
+			}
+		})
+	}
+}
+
+func TestParsePostgresPlaceholdersAreNumbered(t *testing.T) {
+	sql, args, err := parse("SELECT $a, $b", []Param{param("a", 1), param("b", 2)}, PostgresDialect)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if sql != "SELECT $1, $2" {
+		t.Errorf("sql = %q, want %q", sql, "SELECT $1, $2")
+	}
+	if !reflect.DeepEqual(args, []interface{}{1, 2}) {
+		t.Errorf("args = %v, want [1 2]", args)
+	}
+}
+
+func TestParseWhereMacro(t *testing.T) {
+	sql, args, err := parse(
+		"SELECT * FROM users WHERE {where}",
+		[]Param{where_eq("active", true), where_gt("age", 18)},
+		MySQLDialect,
+	)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := "SELECT * FROM users WHERE (`active` = ? AND `age` > ?)"
+	if sql != want {
+		t.Errorf("sql = %q, want %q", sql, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{true, 18}) {
+		t.Errorf("args = %v, want [true 18]", args)
+	}
+}
+
+func TestParseWhereMacroWithNoParamsIsAlwaysTrue(t *testing.T) {
+	sql, args, err := parse("SELECT * FROM users WHERE {where}", nil, MySQLDialect)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if sql != "SELECT * FROM users WHERE 1=1" {
+		t.Errorf("sql = %q, want %q", sql, "SELECT * FROM users WHERE 1=1")
+	}
+	if len(args) != 0 {
+		t.Errorf("args = %v, want none", args)
+	}
+}
+
+func TestParseWhereParamWithoutWhereMacroIsAnError(t *testing.T) {
+	_, _, err := parse("SELECT * FROM users", []Param{where_eq("id", 1)}, MySQLDialect)
+	if err == nil {
+		t.Fatal("parse: want error, got nil")
+	}
+}
+
+func TestParseFieldsAndValuesMacros(t *testing.T) {
+	params := []Param{field("name", "Jason"), field("age", 30)}
+
+	sql, args, err := parse("INSERT INTO users ({fields}) VALUES ({values})", params, MySQLDialect)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := "INSERT INTO users (`name`, `age`) VALUES (?, ?)"
+	if sql != want {
+		t.Errorf("sql = %q, want %q", sql, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"Jason", 30}) {
+		t.Errorf("args = %v, want [Jason 30]", args)
+	}
+}
+
+func TestParseFieldValueMacroWithNoFieldParamsIsAnError(t *testing.T) {
+	_, _, err := parse("UPDATE users SET {field=value}", nil, MySQLDialect)
+	if err == nil {
+		t.Fatal("parse: want error, got nil")
+	}
+}
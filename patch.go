@@ -0,0 +1,139 @@
+package godb1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PatchParam is the family of param builders used by update() to tell
+// "the caller didn't send this field" apart from "the caller explicitly
+// sent an empty string or NULL". field_if_set and field_null are
+// PatchParams; param/field are not, and can be freely mixed with them.
+
+// field_if_set builds a field() param only if present is true. When
+// present is false it returns a PatchParam that update() silently
+// drops from {fields}/{values}/{field=value}, so absent fields never
+// reach the generated SQL.
+func field_if_set(fieldName string, value interface{}, present bool) Param {
+	if !present {
+		return Param{Type: "field_absent", Field: fieldName}
+	}
+	return field(fieldName, value)
+}
+
+// field_null builds a PatchParam that renders as an explicit SQL NULL
+// in {values}/{field=value}, for callers that need to distinguish
+// "don't touch this column" (field_if_set with present=false) from
+// "set this column to NULL" (field_null).
+func field_null(fieldName string) Param {
+	return Param{Type: "field_null", Field: fieldName}
+}
+
+// ParsePatch decodes a JSON object body into field()/field_null()
+// PatchParams, one per key present in body, in the order the keys
+// appear in body. Any key not present in allowed is rejected; keys in
+// allowed that are absent from body simply produce no Param, which is
+// exactly the behavior update() needs to skip them.
+func ParsePatch(body []byte, allowed []string) ([]Param, error) {
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		allowedSet[name] = true
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("godb1: ParsePatch: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("godb1: ParsePatch: body must be a JSON object")
+	}
+
+	var params []Param
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("godb1: ParsePatch: %w", err)
+		}
+		key, _ := keyTok.(string)
+		if !allowedSet[key] {
+			return nil, fmt.Errorf("godb1: ParsePatch: unknown field %q", key)
+		}
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("godb1: ParsePatch: field %q: %w", key, err)
+		}
+		if string(raw) == "null" {
+			params = append(params, field_null(key))
+			continue
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("godb1: ParsePatch: field %q: %w", key, err)
+		}
+		params = append(params, field(key, value))
+	}
+	return params, nil
+}
+
+// FieldsFromPatch builds PatchParams from a pointer to a struct whose
+// fields are themselves pointers, e.g.:
+//
+//	type UserPatch struct {
+//	    Name *string `json:"name"`
+//	    Age  *int    `json:"age"`
+//	}
+//
+// A nil field is treated as absent (field_if_set with present=false); a
+// non-nil field is treated as sent, using the `json` tag (or the
+// snake_case field name) as the column name. This is the typed
+// alternative to ParsePatch for callers that already unmarshal requests
+// into a struct instead of working with raw JSON.
+func FieldsFromPatch(patch interface{}) ([]Param, error) {
+	v := reflect.ValueOf(patch)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("godb1: FieldsFromPatch: expected a pointer to struct, got %T", patch)
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	var params []Param
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" { // unexported
+			continue
+		}
+		fv := v.Field(i)
+		if fv.Kind() != reflect.Ptr {
+			return nil, fmt.Errorf("godb1: FieldsFromPatch: field %q must be a pointer, got %s", sf.Name, fv.Kind())
+		}
+
+		column := columnName(sf)
+		if fv.IsNil() {
+			params = append(params, field_if_set(column, nil, false))
+			continue
+		}
+		params = append(params, field(column, fv.Elem().Interface()))
+	}
+	return params, nil
+}
+
+func columnName(f reflect.StructField) string {
+	if tag := f.Tag.Get("db"); tag != "" && tag != "-" {
+		return tag
+	}
+	if tag := f.Tag.Get("json"); tag != "" && tag != "-" {
+		if comma := strings.IndexByte(tag, ','); comma >= 0 {
+			tag = tag[:comma]
+		}
+		if tag != "" {
+			return tag
+		}
+	}
+	return toSnakeCase(f.Name)
+}
@@ -0,0 +1,125 @@
+package godb1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFieldIfSet(t *testing.T) {
+	p := field_if_set("name", "Jason", true)
+	if p.Type != "field" || p.Field != "name" || p.Value != "Jason" {
+		t.Errorf("present=true: got %+v", p)
+	}
+
+	p = field_if_set("name", "Jason", false)
+	if p.Type != "field_absent" || p.Field != "name" {
+		t.Errorf("present=false: got %+v", p)
+	}
+}
+
+func TestFieldAbsentContributesNothingToFieldsMacro(t *testing.T) {
+	params := []Param{
+		field_if_set("name", "New Name", true),
+		field_if_set("age", 99, false),
+		field_null("bio"),
+	}
+	sql, args, err := parse("UPDATE users SET {field=value} WHERE id = 1", params, MySQLDialect)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := "UPDATE users SET `name` = ?, `bio` = NULL WHERE id = 1"
+	if sql != want {
+		t.Errorf("sql = %q, want %q", sql, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"New Name"}) {
+		t.Errorf("args = %v, want [New Name]", args)
+	}
+}
+
+func TestParsePatch(t *testing.T) {
+	allowed := []string{"name", "age", "bio"}
+
+	params, err := ParsePatch([]byte(`{"name": "Eve", "bio": null}`), allowed)
+	if err != nil {
+		t.Fatalf("ParsePatch: %v", err)
+	}
+	if len(params) != 2 {
+		t.Fatalf("params = %+v, want 2 entries", params)
+	}
+	if params[0].Type != "field" || params[0].Field != "name" || params[0].Value != "Eve" {
+		t.Errorf("params[0] = %+v", params[0])
+	}
+	if params[1].Type != "field_null" || params[1].Field != "bio" {
+		t.Errorf("params[1] = %+v", params[1])
+	}
+
+	// age was absent from body, so it contributes nothing.
+	for _, p := range params {
+		if p.Field == "age" {
+			t.Errorf("age should be absent from params, got %+v", p)
+		}
+	}
+}
+
+func TestParsePatchRejectsUnknownField(t *testing.T) {
+	_, err := ParsePatch([]byte(`{"admin": true}`), []string{"name"})
+	if err == nil {
+		t.Fatal("ParsePatch: want error for disallowed field, got nil")
+	}
+}
+
+func TestParsePatchRejectsNonObjectBody(t *testing.T) {
+	_, err := ParsePatch([]byte(`[1, 2]`), []string{"name"})
+	if err == nil {
+		t.Fatal("ParsePatch: want error for non-object body, got nil")
+	}
+}
+
+func TestFieldsFromPatch(t *testing.T) {
+	name := "Eve"
+	type userPatch struct {
+		Name *string `json:"name"`
+		Age  *int    `json:"age"`
+	}
+	params, err := FieldsFromPatch(&userPatch{Name: &name, Age: nil})
+	if err != nil {
+		t.Fatalf("FieldsFromPatch: %v", err)
+	}
+	if len(params) != 2 {
+		t.Fatalf("params = %+v, want 2 entries", params)
+	}
+	if params[0].Type != "field" || params[0].Field != "name" || params[0].Value != "Eve" {
+		t.Errorf("params[0] = %+v", params[0])
+	}
+	if params[1].Type != "field_absent" || params[1].Field != "age" {
+		t.Errorf("params[1] = %+v", params[1])
+	}
+}
+
+func TestFieldsFromPatchRejectsNonPointerField(t *testing.T) {
+	type badPatch struct {
+		Name string `json:"name"`
+	}
+	_, err := FieldsFromPatch(&badPatch{Name: "Eve"})
+	if err == nil {
+		t.Fatal("FieldsFromPatch: want error for non-pointer field, got nil")
+	}
+}
+
+func TestColumnNamePrefersDBTagThenJSONTagThenSnakeCase(t *testing.T) {
+	type row struct {
+		A string `db:"a_col" json:"a_json"`
+		B string `json:"b_json,omitempty"`
+		C string
+	}
+	rt := reflect.TypeOf(row{})
+	if got := columnName(rt.Field(0)); got != "a_col" {
+		t.Errorf("db tag: got %q, want %q", got, "a_col")
+	}
+	if got := columnName(rt.Field(1)); got != "b_json" {
+		t.Errorf("json tag: got %q, want %q", got, "b_json")
+	}
+	if got := columnName(rt.Field(2)); got != "c" {
+		t.Errorf("no tag: got %q, want %q", got, "c")
+	}
+}
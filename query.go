@@ -0,0 +1,247 @@
+package godb1
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// ErrNoRows is returned by Value and Row when the query matched zero
+// rows. It is an alias for sql.ErrNoRows so callers can keep using the
+// familiar sentinel from database/sql.
+var ErrNoRows = sql.ErrNoRows
+
+// DBTX is satisfied by *sql.DB, *sql.Tx and Tx. Every query helper in
+// this file takes one explicitly instead of reaching for a package-level
+// connection, so callers can run the same helper inside or outside a
+// transaction.
+type DBTX interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// Value runs sqlTemplate and returns exactly one scalar column. If the
+// query matches no rows it returns ErrNoRows.
+func Value[T any](db DBTX, sqlTemplate string, params ...Param) (T, error) {
+	var zero T
+	finalSQL, args, err := resolve(sqlTemplate, params)
+	if err != nil {
+		return zero, err
+	}
+	row, err := dbQueryRow(db, finalSQL, args)
+	if err != nil {
+		return zero, err
+	}
+	var v T
+	if err := row.Scan(&v); err != nil {
+		return zero, err
+	}
+	return v, nil
+}
+
+// ValueNil is like Value but returns (nil, nil) instead of ErrNoRows
+// when the query matches no rows.
+func ValueNil[T any](db DBTX, sqlTemplate string, params ...Param) (*T, error) {
+	finalSQL, args, err := resolve(sqlTemplate, params)
+	if err != nil {
+		return nil, err
+	}
+	row, err := dbQueryRow(db, finalSQL, args)
+	if err != nil {
+		return nil, err
+	}
+	var v T
+	err = row.Scan(&v)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// ValueList runs sqlTemplate and returns every row's single column as a
+// slice.
+func ValueList[T any](db DBTX, sqlTemplate string, params ...Param) ([]T, error) {
+	finalSQL, args, err := resolve(sqlTemplate, params)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := dbQuery(db, finalSQL, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []T
+	for rows.Next() {
+		var v T
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// Row runs sqlTemplate and scans exactly one row into a struct of type
+// T using column-name -> exported-field matching. If the query matches
+// no rows it returns ErrNoRows.
+func Row[T any](db DBTX, sqlTemplate string, params ...Param) (T, error) {
+	var zero T
+	finalSQL, args, err := resolve(sqlTemplate, params)
+	if err != nil {
+		return zero, err
+	}
+	rows, err := dbQuery(db, finalSQL, args)
+	if err != nil {
+		return zero, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return zero, err
+		}
+		return zero, ErrNoRows
+	}
+
+	var v T
+	if err := scanOne(rows, &v); err != nil {
+		return zero, err
+	}
+	return v, rows.Err()
+}
+
+// RowNil is like Row but returns (nil, nil) instead of ErrNoRows when
+// the query matches no rows.
+func RowNil[T any](db DBTX, sqlTemplate string, params ...Param) (*T, error) {
+	v, err := Row[T](db, sqlTemplate, params...)
+	if err == ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// RowList runs sqlTemplate and scans every row into a struct of type T,
+// returning the results as a slice.
+func RowList[T any](db DBTX, sqlTemplate string, params ...Param) ([]T, error) {
+	finalSQL, args, err := resolve(sqlTemplate, params)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := dbQuery(db, finalSQL, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []T
+	for rows.Next() {
+		var v T
+		if err := scanOne(rows, &v); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// Exec runs sqlTemplate against db and discards the result. It is the
+// DBTX counterpart of Execute/Insert/Update/Delete, for callers --
+// notably generated code -- that pass their connection explicitly
+// instead of relying on SetDB's package-level connection.
+func Exec(db DBTX, sqlTemplate string, params ...Param) error {
+	finalSQL, args, err := resolve(sqlTemplate, params)
+	if err != nil {
+		return err
+	}
+	_, err = dbExec(db, finalSQL, args)
+	return err
+}
+
+// InsertRow is Insert's DBTX counterpart: INSERT INTO table ({fields})
+// VALUES ({values}) against an explicit connection.
+func InsertRow(db DBTX, table string, params ...Param) error {
+	return Exec(db, fmt.Sprintf("INSERT INTO %s ({fields}) VALUES ({values})", quote_ident(table)), params...)
+}
+
+// UpdateRow is Update's DBTX counterpart.
+func UpdateRow(db DBTX, table string, params ...Param) error {
+	return Exec(db, fmt.Sprintf("UPDATE %s SET {field=value} WHERE {where}", quote_ident(table)), params...)
+}
+
+// DeleteRow is Delete's DBTX counterpart.
+func DeleteRow(db DBTX, table string, params ...Param) error {
+	return Exec(db, fmt.Sprintf("DELETE FROM %s WHERE {where}", quote_ident(table)), params...)
+}
+
+// FindRows is RowList's table-based counterpart: SELECT * FROM table
+// WHERE {where}, with table quoted via the configured dialect the same
+// way InsertRow/UpdateRow/DeleteRow quote it, for callers -- notably
+// generated Find wrappers -- that only have a table name and where
+// params rather than a full SQL template.
+func FindRows[T any](db DBTX, table string, where ...Param) ([]T, error) {
+	return RowList[T](db, fmt.Sprintf("SELECT * FROM %s WHERE {where}", quote_ident(table)), where...)
+}
+
+// InsertRowReturning is InsertReturning's DBTX counterpart: it inserts
+// params and scans the named returning columns back into dest. On
+// dialects without RETURNING (MySQL), returning must name exactly the
+// table's auto-increment column and dest[0] must be *int64 or *int.
+func InsertRowReturning(db DBTX, table string, returning []string, dest []interface{}, params ...Param) error {
+	if len(returning) != len(dest) {
+		return fmt.Errorf("godb1: InsertRowReturning: %d returning columns but %d dest pointers", len(returning), len(dest))
+	}
+
+	sqlTemplate := fmt.Sprintf("INSERT INTO %s ({fields}) VALUES ({values})", quote_ident(table))
+	finalSQL, args, err := resolve(sqlTemplate, params)
+	if err != nil {
+		return err
+	}
+
+	if !defaultDialect.SupportsReturning {
+		if len(returning) != 1 {
+			return fmt.Errorf("godb1: InsertRowReturning: dialect %q has no RETURNING, only the single auto-increment id can be returned", defaultDialect.Name)
+		}
+		result, err := dbExec(db, finalSQL, args)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		return assignInt64(dest[0], id)
+	}
+
+	quoted := make([]string, len(returning))
+	for i, col := range returning {
+		quoted[i] = quote_ident(col)
+	}
+	finalSQL += " RETURNING " + strings.Join(quoted, ", ")
+	row, err := dbQueryRow(db, finalSQL, args)
+	if err != nil {
+		return err
+	}
+	return row.Scan(dest...)
+}
+
+// scanOne scans the current row of rows into dest, a pointer to a
+// struct, matching columns to exported fields by name.
+func scanOne(rows *sql.Rows, dest interface{}) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	scanArgs, err := scanStruct(dest, cols)
+	if err != nil {
+		return err
+	}
+	return rows.Scan(scanArgs...)
+}
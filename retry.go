@@ -0,0 +1,71 @@
+package godb1
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+// serializationFailureSQLState and deadlockSQLState are the Postgres/
+// CockroachDB SQLSTATE codes RunSerializable retries on.
+const (
+	serializationFailureSQLState = "40001"
+	deadlockSQLState             = "40P01"
+)
+
+// sqlStater is implemented by most Postgres driver error types
+// (lib/pq's *pq.Error, jackc/pgx's *pgconn.PgError, ...).
+type sqlStater interface {
+	SQLState() string
+}
+
+func isRetryableSerializationError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var s sqlStater
+	if errors.As(err, &s) {
+		code := s.SQLState()
+		return code == serializationFailureSQLState || code == deadlockSQLState
+	}
+	// Fallback for drivers that don't expose a typed SQLSTATE: the code
+	// still shows up in the formatted error text.
+	msg := err.Error()
+	return strings.Contains(msg, serializationFailureSQLState) || strings.Contains(msg, deadlockSQLState)
+}
+
+// RunSerializable runs fn in a SERIALIZABLE transaction on db, retrying
+// the whole top-level transaction with exponential backoff when the
+// driver reports a serialization failure (40001) or deadlock (40P01).
+// Only a fresh top-level Do is ever retried: by the time RunSerializable
+// sees the error, fn's transaction has already been rolled back by Do,
+// so nothing partially committed is ever re-run. maxRetries is the
+// number of retries after the initial attempt; a maxRetries of 0 means
+// "try once, don't retry".
+func RunSerializable(ctx context.Context, db *sql.DB, maxRetries int, fn func(ctx context.Context, tx *Tx) error) error {
+	opts := &sql.TxOptions{Isolation: sql.LevelSerializable}
+	backoff := 25 * time.Millisecond
+	const maxBackoff = 2 * time.Second
+
+	for attempt := 0; ; attempt++ {
+		err := doBegin(ctx, db, opts, fn)
+		if err == nil {
+			return nil
+		}
+		if attempt >= maxRetries || !isRetryableSerializationError(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
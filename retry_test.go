@@ -0,0 +1,36 @@
+package godb1
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type fakeSQLStateErr struct{ state string }
+
+func (e *fakeSQLStateErr) Error() string    { return "sql state " + e.state }
+func (e *fakeSQLStateErr) SQLState() string { return e.state }
+
+func TestIsRetryableSerializationError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil error", nil, false},
+		{"typed serialization failure", &fakeSQLStateErr{serializationFailureSQLState}, true},
+		{"typed deadlock", &fakeSQLStateErr{deadlockSQLState}, true},
+		{"typed unrelated SQLSTATE", &fakeSQLStateErr{"23505"}, false},
+		{"wrapped typed serialization failure", fmt.Errorf("query failed: %w", &fakeSQLStateErr{serializationFailureSQLState}), true},
+		{"untyped message fallback matches", errors.New("ERROR: could not serialize access due to concurrent update (SQLSTATE 40001)"), true},
+		{"untyped message fallback no match", errors.New("connection refused"), false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := isRetryableSerializationError(c.err)
+			if got != c.want {
+				t.Errorf("isRetryableSerializationError(%v) = %v, want %v", c.err, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package godb1
+
+import "database/sql"
+
+// Rows wraps *sql.Rows so result sets can be walked with Each/MustScans
+// instead of allocating a full []T up front, the way go-sx's
+// MustQuery(...).Each(...) does.
+type Rows struct {
+	rows *sql.Rows
+	err  error
+}
+
+// MustQuery runs sqlTemplate against db and returns a *Rows ready for
+// Each. Parse and query errors are deferred to the first call on the
+// result rather than returned here, matching the "Must" naming: callers
+// that want an error back should use RowList/ValueList instead.
+func MustQuery(db DBTX, sqlTemplate string, params ...Param) *Rows {
+	finalSQL, args, err := resolve(sqlTemplate, params)
+	if err != nil {
+		return &Rows{err: err}
+	}
+	rows, err := dbQuery(db, finalSQL, args)
+	if err != nil {
+		return &Rows{err: err}
+	}
+	return &Rows{rows: rows}
+}
+
+// Each calls fn once per result row, closing the underlying *sql.Rows
+// when done. It returns the first error encountered, whether from the
+// original query or from iterating the driver's result set.
+func (r *Rows) Each(fn func(r *Rows)) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer r.rows.Close()
+	for r.rows.Next() {
+		fn(r)
+	}
+	return r.rows.Err()
+}
+
+// MustScans scans the current row into dest, panicking on error. It is
+// meant to be called exactly once per Each callback, mirroring go-sx's
+// MustScans ergonomics for the common case where a scan failure means
+// the query and the struct have drifted apart and should fail loudly.
+func (r *Rows) MustScans(dest ...interface{}) {
+	if err := r.rows.Scan(dest...); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,76 @@
+package godb1
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// toSnakeCase converts an exported Go field name such as "UserID" or
+// "CreateDate" into the snake_case column name a typical schema would
+// use: "user_id", "create_date".
+func toSnakeCase(name string) string {
+	var out strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			prev := runes[i-1]
+			isBoundary := prev >= 'a' && prev <= 'z'
+			if !isBoundary && i+1 < len(runes) {
+				next := runes[i+1]
+				isBoundary = next >= 'a' && next <= 'z'
+			}
+			if isBoundary {
+				out.WriteByte('_')
+			}
+		}
+		out.WriteRune(r)
+	}
+	return strings.ToLower(out.String())
+}
+
+// fieldIndexForColumn finds the struct field that column should scan
+// into: an explicit `db:"..."` tag wins, otherwise the field whose name
+// matches the column case-insensitively or whose snake_case form
+// matches the column.
+func fieldIndexForColumn(t reflect.Type, column string) (int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" { // unexported
+			continue
+		}
+		if tag := f.Tag.Get("db"); tag != "" {
+			if tag == column {
+				return i, true
+			}
+			continue
+		}
+		if strings.EqualFold(f.Name, column) || strings.EqualFold(toSnakeCase(f.Name), column) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// scanStruct scans one database row, whose column names are cols, into
+// dest (a pointer to a struct). Every column must map to an exported
+// field; an unmapped column is treated as a bug in the query or the
+// struct and returned as an error rather than silently dropped.
+func scanStruct(dest interface{}, cols []string) (scanArgs []interface{}, err error) {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("godb1: scan destination must be a pointer to struct, got %T", dest)
+	}
+	elem := v.Elem()
+	t := elem.Type()
+
+	scanArgs = make([]interface{}, len(cols))
+	for i, col := range cols {
+		idx, ok := fieldIndexForColumn(t, col)
+		if !ok {
+			return nil, fmt.Errorf("godb1: no exported field on %s matches column %q", t.Name(), col)
+		}
+		scanArgs[i] = elem.Field(idx).Addr().Interface()
+	}
+	return scanArgs, nil
+}
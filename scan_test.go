@@ -0,0 +1,79 @@
+package godb1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToSnakeCase(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"single word", "Name", "name"},
+		{"two words", "UserID", "user_id"},
+		{"three words", "CreateDate", "create_date"},
+		{"acronym stays together", "ID", "id"},
+		{"acronym then word", "IDNumber", "id_number"},
+		{"word then acronym", "UserIDNumber", "user_id_number"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := toSnakeCase(c.in)
+			if got != c.want {
+				t.Errorf("toSnakeCase(%q) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func TestFieldIndexForColumn(t *testing.T) {
+	type row struct {
+		ID        int
+		UserName  string `db:"username"`
+		CreatedAt string
+		unexposed string
+	}
+	typ := reflect.TypeOf(row{})
+
+	cases := []struct {
+		name    string
+		column  string
+		wantIdx int
+		wantOK  bool
+	}{
+		{"exact field name, case-insensitive", "id", 0, true},
+		{"db tag wins over name match", "username", 1, true},
+		{"snake_case match", "created_at", 2, true},
+		{"unexported field never matches", "unexposed", 0, false},
+		{"no matching column", "nope", 0, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			idx, ok := fieldIndexForColumn(typ, c.column)
+			if ok != c.wantOK || (ok && idx != c.wantIdx) {
+				t.Errorf("fieldIndexForColumn(%q) = (%d, %v), want (%d, %v)", c.column, idx, ok, c.wantIdx, c.wantOK)
+			}
+		})
+	}
+}
+
+func TestScanStructRejectsNonStructPointer(t *testing.T) {
+	var x int
+	_, err := scanStruct(&x, []string{"id"})
+	if err == nil {
+		t.Fatal("scanStruct: want error for non-struct destination, got nil")
+	}
+}
+
+func TestScanStructRejectsUnmappedColumn(t *testing.T) {
+	type row struct {
+		ID int
+	}
+	var r row
+	_, err := scanStruct(&r, []string{"id", "missing_column"})
+	if err == nil {
+		t.Fatal("scanStruct: want error for unmapped column, got nil")
+	}
+}
@@ -0,0 +1,141 @@
+package godb1
+
+import (
+	"database/sql"
+	"sync"
+)
+
+// stmtCacheKey identifies a prepared statement by the final SQL it was
+// prepared from and the connection (*sql.DB) it was prepared against.
+// The same template can resolve to different final SQL depending on
+// which params were supplied (e.g. a 3-element vs 4-element IN list),
+// so the cache is keyed on the resolved SQL, not the template.
+type stmtCacheKey struct {
+	connID int
+	sql    string
+}
+
+// stmtCache is a sync.Mutex-guarded map from (final_sql, conn_id) to a
+// prepared *sql.Stmt. It is safe for concurrent use.
+type stmtCache struct {
+	mu    sync.Mutex
+	stmts map[stmtCacheKey]*sql.Stmt
+}
+
+func newStmtCache() *stmtCache {
+	return &stmtCache{stmts: make(map[stmtCacheKey]*sql.Stmt)}
+}
+
+// globalStmtCache backs every *sql.DB that goes through connIDs below.
+var globalStmtCache = newStmtCache()
+
+var (
+	connIDsMu  sync.Mutex
+	connIDs    = map[*sql.DB]int{}
+	nextConnID = 1
+)
+
+// connID returns a small stable integer identifying db, assigning one
+// the first time db is seen.
+func connID(db *sql.DB) int {
+	connIDsMu.Lock()
+	defer connIDsMu.Unlock()
+	if id, ok := connIDs[db]; ok {
+		return id
+	}
+	id := nextConnID
+	nextConnID++
+	connIDs[db] = id
+	return id
+}
+
+// prepare returns a cached *sql.Stmt for finalSQL against db, preparing
+// and caching it on first use.
+func (c *stmtCache) prepare(db *sql.DB, finalSQL string) (*sql.Stmt, error) {
+	key := stmtCacheKey{connID: connID(db), sql: finalSQL}
+
+	c.mu.Lock()
+	stmt, ok := c.stmts[key]
+	c.mu.Unlock()
+	if ok {
+		return stmt, nil
+	}
+
+	stmt, err := db.Prepare(finalSQL)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.stmts[key]; ok {
+		stmt.Close()
+		return existing, nil
+	}
+	c.stmts[key] = stmt
+	return stmt, nil
+}
+
+// cachedStmt returns a statement for finalSQL pre-bound to db, reusing
+// globalStmtCache the same way runExec does, or (nil, false, nil) if db's
+// concrete type carries no connection identity the cache can key on (a
+// bare *sql.Tx passed in directly, rather than through Do's *Tx).
+//
+// For a *Tx, the db-level *sql.Stmt is cached exactly as for *sql.DB,
+// then bound into the transaction with Tx.Stmt -- the standard
+// database/sql idiom for reusing a prepared statement inside a
+// transaction instead of re-preparing on the tx's dedicated connection.
+func cachedStmt(db DBTX, finalSQL string) (*sql.Stmt, bool, error) {
+	switch d := db.(type) {
+	case *sql.DB:
+		stmt, err := globalStmtCache.prepare(d, finalSQL)
+		return stmt, true, err
+	case *Tx:
+		stmt, err := globalStmtCache.prepare(d.db, finalSQL)
+		if err != nil {
+			return nil, true, err
+		}
+		return d.Tx.Stmt(stmt), true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// dbQuery is db.Query(finalSQL, args...), routed through the prepared
+// statement cache when db's type supports it.
+func dbQuery(db DBTX, finalSQL string, args []interface{}) (*sql.Rows, error) {
+	stmt, cached, err := cachedStmt(db, finalSQL)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return stmt.Query(args...)
+	}
+	return db.Query(finalSQL, args...)
+}
+
+// dbQueryRow is db.QueryRow(finalSQL, args...), routed through the
+// prepared statement cache when db's type supports it.
+func dbQueryRow(db DBTX, finalSQL string, args []interface{}) (*sql.Row, error) {
+	stmt, cached, err := cachedStmt(db, finalSQL)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return stmt.QueryRow(args...), nil
+	}
+	return db.QueryRow(finalSQL, args...), nil
+}
+
+// dbExec is db.Exec(finalSQL, args...), routed through the prepared
+// statement cache when db's type supports it.
+func dbExec(db DBTX, finalSQL string, args []interface{}) (sql.Result, error) {
+	stmt, cached, err := cachedStmt(db, finalSQL)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return stmt.Exec(args...)
+	}
+	return db.Exec(finalSQL, args...)
+}
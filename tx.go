@@ -0,0 +1,98 @@
+package godb1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+)
+
+// Tx wraps *sql.Tx so it can grow transaction-scoped behavior (the
+// savepoint counter, retry bookkeeping, ...) without changing the
+// signature every caller of Do already uses.
+type Tx struct {
+	*sql.Tx
+	db           *sql.DB
+	savepointSeq *int64
+}
+
+// SavepointTx is the bookkeeping for one nested Do call: the name of
+// the SAVEPOINT it issued, so it can ROLLBACK TO or RELEASE the right
+// one regardless of how deep the nesting goes.
+type SavepointTx struct {
+	*Tx
+	name string
+}
+
+// Name returns the SAVEPOINT identifier this nesting level issued.
+func (s *SavepointTx) Name() string {
+	return s.name
+}
+
+type txCtxKey struct{}
+
+// Do runs fn inside a transaction. The first Do for a given ctx issues
+// BEGIN/COMMIT (or ROLLBACK) on db. Any Do called with a ctx derived
+// from that one - directly or through several layers of function calls
+// - detects the transaction already in flight and instead wraps fn in
+// SAVEPOINT/RELEASE SAVEPOINT/ROLLBACK TO SAVEPOINT, so transactional
+// code can call other transactional code without knowing whether it's
+// already inside a transaction.
+func Do(ctx context.Context, db *sql.DB, fn func(ctx context.Context, tx *Tx) error) error {
+	if parent, ok := ctx.Value(txCtxKey{}).(*Tx); ok {
+		return doSavepoint(ctx, parent, fn)
+	}
+	return doBegin(ctx, db, nil, fn)
+}
+
+func doBegin(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(ctx context.Context, tx *Tx) error) (err error) {
+	sqlTx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	var seq int64
+	tx := &Tx{Tx: sqlTx, db: db, savepointSeq: &seq}
+	ctx = context.WithValue(ctx, txCtxKey{}, tx)
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(ctx, tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return rbErr
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func doSavepoint(ctx context.Context, tx *Tx, fn func(ctx context.Context, tx *Tx) error) (err error) {
+	n := atomic.AddInt64(tx.savepointSeq, 1)
+	sp := &SavepointTx{Tx: tx, name: fmt.Sprintf("sp_%d", n)}
+
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+sp.name); err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+sp.name)
+			panic(p)
+		}
+	}()
+
+	if err = fn(ctx, tx); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+sp.name); rbErr != nil {
+			return rbErr
+		}
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+sp.name); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package godb1
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+// TestSavepointNamesIncreasePerTx exercises the same savepointSeq
+// counter doSavepoint uses, without needing a live *sql.Tx to call
+// ExecContext against.
+func TestSavepointNamesIncreasePerTx(t *testing.T) {
+	var seq int64
+	tx := &Tx{savepointSeq: &seq}
+
+	var names []string
+	for i := 0; i < 3; i++ {
+		n := atomic.AddInt64(tx.savepointSeq, 1)
+		sp := &SavepointTx{Tx: tx, name: fmt.Sprintf("sp_%d", n)}
+		names = append(names, sp.Name())
+	}
+
+	want := []string{"sp_1", "sp_2", "sp_3"}
+	for i, n := range names {
+		if n != want[i] {
+			t.Errorf("names[%d] = %q, want %q", i, n, want[i])
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package godb1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// where_in matches rows where field is one of values. An empty values
+// slice can never match anything, so it renders as the short-circuit
+// "1=0" instead of the syntactically invalid "IN ()".
+func where_in[T any](field string, values []T) Param {
+	return Param{Type: "where_in", Field: field, Values: toInterfaceSlice(values)}
+}
+
+// where_not_in matches rows where field is none of values. An empty
+// values slice excludes nothing, so it renders as "1=1".
+func where_not_in[T any](field string, values []T) Param {
+	return Param{Type: "where_not_in", Field: field, Values: toInterfaceSlice(values)}
+}
+
+// where_between matches field BETWEEN lo AND hi (inclusive, per SQL).
+func where_between[T any](field string, lo, hi T) Param {
+	return Param{Type: "where_between", Field: field, Values: []interface{}{lo, hi}}
+}
+
+// where_like matches field LIKE pattern.
+func where_like(field string, pattern string) Param {
+	return Param{Type: "where_like", Field: field, Value: pattern}
+}
+
+// where_ilike matches field ILIKE pattern (Postgres/CockroachDB). On
+// dialects without ILIKE it falls back to LOWER(field) LIKE LOWER(pattern).
+func where_ilike(field string, pattern string) Param {
+	return Param{Type: "where_ilike", Field: field, Value: pattern}
+}
+
+// where_raw is the escape hatch for a where clause this package's
+// builders don't cover. sqlFragment uses "?" for each bound value,
+// e.g. where_raw("age BETWEEN ? AND ?", 30, 40); the "?" is rewritten
+// to the configured dialect's real placeholder at render time, the
+// same as every other where_* helper.
+func where_raw(sqlFragment string, values ...interface{}) Param {
+	return Param{Type: "where_raw", Sql: sqlFragment, Values: values}
+}
+
+// where_any groups clauses with OR: where_any(where_eq("a", 1), where_eq("b", 2))
+// renders as "(a = ? OR b = ?)".
+func where_any(clauses ...Param) Param {
+	return Param{Type: "where_any", Children: clauses}
+}
+
+// where_all groups clauses with AND and wraps them in their own
+// parentheses, e.g. for mixing with where_any:
+// where_any(where_all(where_eq("a", 1), where_eq("b", 2)), where_eq("c", 3))
+func where_all(clauses ...Param) Param {
+	return Param{Type: "where_all", Children: clauses}
+}
+
+func toInterfaceSlice[T any](values []T) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+// renderWhereParam renders a single where_* param (recursing into
+// where_any/where_all's children) into a SQL clause plus its bound
+// args, using placeholders numbered from argOffset+1.
+func renderWhereParam(p Param, d Dialect, argOffset int) (string, []interface{}, error) {
+	ident := d.QuoteIdent(p.Field)
+
+	switch p.Type {
+	case "where_null":
+		return ident + " IS NULL", nil, nil
+	case "where_not_null":
+		return ident + " IS NOT NULL", nil, nil
+
+	case "where_eq":
+		return fmt.Sprintf("%s = %s", ident, d.Placeholder(argOffset+1)), []interface{}{p.Value}, nil
+	case "where_ne":
+		return fmt.Sprintf("%s != %s", ident, d.Placeholder(argOffset+1)), []interface{}{p.Value}, nil
+	case "where_gt":
+		return fmt.Sprintf("%s > %s", ident, d.Placeholder(argOffset+1)), []interface{}{p.Value}, nil
+	case "where_gte":
+		return fmt.Sprintf("%s >= %s", ident, d.Placeholder(argOffset+1)), []interface{}{p.Value}, nil
+	case "where_lt":
+		return fmt.Sprintf("%s < %s", ident, d.Placeholder(argOffset+1)), []interface{}{p.Value}, nil
+	case "where_lte":
+		return fmt.Sprintf("%s <= %s", ident, d.Placeholder(argOffset+1)), []interface{}{p.Value}, nil
+
+	case "where_like":
+		return fmt.Sprintf("%s LIKE %s", ident, d.Placeholder(argOffset+1)), []interface{}{p.Value}, nil
+	case "where_ilike":
+		if d.Name == "postgres" || d.Name == "cockroachdb" {
+			return fmt.Sprintf("%s ILIKE %s", ident, d.Placeholder(argOffset+1)), []interface{}{p.Value}, nil
+		}
+		return fmt.Sprintf("LOWER(%s) LIKE LOWER(%s)", ident, d.Placeholder(argOffset+1)), []interface{}{p.Value}, nil
+
+	case "where_between":
+		if len(p.Values) != 2 {
+			return "", nil, fmt.Errorf("godb1: where_between(%q) needs exactly 2 values, got %d", p.Field, len(p.Values))
+		}
+		return fmt.Sprintf("%s BETWEEN %s AND %s", ident, d.Placeholder(argOffset+1), d.Placeholder(argOffset+2)), p.Values, nil
+
+	case "where_in", "where_not_in":
+		return renderWhereInList(p, d, argOffset)
+
+	case "where_raw":
+		return renderWhereRaw(p, d, argOffset)
+
+	case "where_any", "where_all":
+		return renderWhereGroup(p, d, argOffset)
+
+	default:
+		return "", nil, fmt.Errorf("godb1: %q is not a where_* param and cannot appear in {where}", p.Type)
+	}
+}
+
+func renderWhereInList(p Param, d Dialect, argOffset int) (string, []interface{}, error) {
+	if len(p.Values) == 0 {
+		// IN () and NOT IN () are invalid SQL; render the clause that
+		// means the same thing instead.
+		if p.Type == "where_in" {
+			return "1=0", nil, nil
+		}
+		return "1=1", nil, nil
+	}
+
+	placeholders := make([]string, len(p.Values))
+	for i := range p.Values {
+		placeholders[i] = d.Placeholder(argOffset + i + 1)
+	}
+	op := "IN"
+	if p.Type == "where_not_in" {
+		op = "NOT IN"
+	}
+	return fmt.Sprintf("%s %s (%s)", d.QuoteIdent(p.Field), op, strings.Join(placeholders, ", ")), p.Values, nil
+}
+
+func renderWhereRaw(p Param, d Dialect, argOffset int) (string, []interface{}, error) {
+	var sb strings.Builder
+	placeholderCount := 0
+	for _, r := range p.Sql {
+		if r == '?' {
+			placeholderCount++
+			sb.WriteString(d.Placeholder(argOffset + placeholderCount))
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	if placeholderCount != len(p.Values) {
+		return "", nil, fmt.Errorf("godb1: where_raw(%q) has %d \"?\" placeholders but %d values were given", p.Sql, placeholderCount, len(p.Values))
+	}
+	return sb.String(), p.Values, nil
+}
+
+func renderWhereGroup(p Param, d Dialect, argOffset int) (string, []interface{}, error) {
+	if len(p.Children) == 0 {
+		return "", nil, fmt.Errorf("godb1: %s has no sub-clauses", p.Type)
+	}
+	joiner := " AND "
+	if p.Type == "where_any" {
+		joiner = " OR "
+	}
+
+	var clauses []string
+	var args []interface{}
+	for _, child := range p.Children {
+		clause, clauseArgs, err := renderWhereParam(child, d, argOffset+len(args))
+		if err != nil {
+			return "", nil, err
+		}
+		clauses = append(clauses, clause)
+		args = append(args, clauseArgs...)
+	}
+	return "(" + strings.Join(clauses, joiner) + ")", args, nil
+}
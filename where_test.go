@@ -0,0 +1,140 @@
+package godb1
+
+import "testing"
+
+func TestWhereInEmptyValuesShortCircuits(t *testing.T) {
+	clause, args, err := renderWhereParam(where_in[int]("id", nil), MySQLDialect, 0)
+	if err != nil {
+		t.Fatalf("renderWhereParam: %v", err)
+	}
+	if clause != "1=0" || len(args) != 0 {
+		t.Errorf("clause = %q args = %v, want \"1=0\" and no args", clause, args)
+	}
+}
+
+func TestWhereNotInEmptyValuesShortCircuits(t *testing.T) {
+	clause, args, err := renderWhereParam(where_not_in[int]("id", nil), MySQLDialect, 0)
+	if err != nil {
+		t.Fatalf("renderWhereParam: %v", err)
+	}
+	if clause != "1=1" || len(args) != 0 {
+		t.Errorf("clause = %q args = %v, want \"1=1\" and no args", clause, args)
+	}
+}
+
+func TestWhereInExpandsOnePlaceholderPerValue(t *testing.T) {
+	clause, args, err := renderWhereParam(where_in("status", []string{"active", "trial", "past_due"}), MySQLDialect, 0)
+	if err != nil {
+		t.Fatalf("renderWhereParam: %v", err)
+	}
+	if clause != "`status` IN (?, ?, ?)" {
+		t.Errorf("clause = %q", clause)
+	}
+	if len(args) != 3 || args[0] != "active" || args[1] != "trial" || args[2] != "past_due" {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestWhereNotInUsesNotInKeyword(t *testing.T) {
+	clause, _, err := renderWhereParam(where_not_in("id", []int{4, 5, 6}), MySQLDialect, 0)
+	if err != nil {
+		t.Fatalf("renderWhereParam: %v", err)
+	}
+	if clause != "`id` NOT IN (?, ?, ?)" {
+		t.Errorf("clause = %q", clause)
+	}
+}
+
+func TestWhereBetween(t *testing.T) {
+	clause, args, err := renderWhereParam(where_between("age", 18, 65), PostgresDialect, 0)
+	if err != nil {
+		t.Fatalf("renderWhereParam: %v", err)
+	}
+	if clause != `"age" BETWEEN $1 AND $2` {
+		t.Errorf("clause = %q", clause)
+	}
+	if len(args) != 2 || args[0] != 18 || args[1] != 65 {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestWhereILikeDialectFallback(t *testing.T) {
+	clause, _, err := renderWhereParam(where_ilike("name", "a%"), PostgresDialect, 0)
+	if err != nil {
+		t.Fatalf("renderWhereParam: %v", err)
+	}
+	if clause != `"name" ILIKE $1` {
+		t.Errorf("postgres clause = %q", clause)
+	}
+
+	clause, _, err = renderWhereParam(where_ilike("name", "a%"), MySQLDialect, 0)
+	if err != nil {
+		t.Fatalf("renderWhereParam: %v", err)
+	}
+	if clause != "LOWER(`name`) LIKE LOWER(?)" {
+		t.Errorf("mysql clause = %q", clause)
+	}
+}
+
+func TestWhereRaw(t *testing.T) {
+	clause, args, err := renderWhereParam(where_raw("lower(email) = ?", "admin@example.com"), MySQLDialect, 0)
+	if err != nil {
+		t.Fatalf("renderWhereParam: %v", err)
+	}
+	if clause != "lower(email) = ?" {
+		t.Errorf("clause = %q", clause)
+	}
+	if len(args) != 1 || args[0] != "admin@example.com" {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestWhereRawPlaceholderCountMismatchIsAnError(t *testing.T) {
+	_, _, err := renderWhereParam(where_raw("a = ? AND b = ?", 1), MySQLDialect, 0)
+	if err == nil {
+		t.Fatal("renderWhereParam: want error for placeholder/value count mismatch, got nil")
+	}
+}
+
+func TestWhereAnyOrsClauses(t *testing.T) {
+	clause, args, err := renderWhereParam(
+		where_any(where_eq("a", 1), where_eq("b", 2)),
+		MySQLDialect, 0,
+	)
+	if err != nil {
+		t.Fatalf("renderWhereParam: %v", err)
+	}
+	if clause != "(`a` = ? OR `b` = ?)" {
+		t.Errorf("clause = %q", clause)
+	}
+	if len(args) != 2 || args[0] != 1 || args[1] != 2 {
+		t.Errorf("args = %v", args)
+	}
+}
+
+func TestWhereAllAndsClausesAndNests(t *testing.T) {
+	clause, args, err := renderWhereParam(
+		where_any(
+			where_all(where_in("status", []string{"active", "trial"}), where_between("age", 18, 65)),
+			where_like("name", "A%"),
+		),
+		MySQLDialect, 0,
+	)
+	if err != nil {
+		t.Fatalf("renderWhereParam: %v", err)
+	}
+	want := "((`status` IN (?, ?) AND `age` BETWEEN ? AND ?) OR `name` LIKE ?)"
+	if clause != want {
+		t.Errorf("clause = %q, want %q", clause, want)
+	}
+	if len(args) != 5 {
+		t.Errorf("args = %v, want 5 values", args)
+	}
+}
+
+func TestWhereGroupWithNoChildrenIsAnError(t *testing.T) {
+	_, _, err := renderWhereParam(where_any(), MySQLDialect, 0)
+	if err == nil {
+		t.Fatal("renderWhereParam: want error for empty where_any, got nil")
+	}
+}